@@ -0,0 +1,3 @@
+data "jiraassets_object_attachments" "example" {
+  object_id = "1234"
+}
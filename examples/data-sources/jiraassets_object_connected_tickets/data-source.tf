@@ -0,0 +1,7 @@
+data "jiraassets_object_connected_tickets" "example" {
+  object_id = jiraassets_object.example_object.id
+}
+
+output "open_tickets" {
+  value = [for ticket in data.jiraassets_object_connected_tickets.example.tickets : ticket.key if ticket.open]
+}
@@ -0,0 +1,5 @@
+data "jiraassets_object_history" "example" {
+  object_id = jiraassets_object.example_object.id
+  attribute = "Status"
+  since     = "2024-01-01T00:00:00Z"
+}
@@ -0,0 +1,10 @@
+ephemeral "jiraassets_object" "db_credentials" {
+  object_key = "ITSM-1234"
+  attributes = ["Admin User", "Admin Password"]
+}
+
+provider "postgresql" {
+  host     = "db01.example.com"
+  username = ephemeral.jiraassets_object.db_credentials.values["Admin User"]
+  password = ephemeral.jiraassets_object.db_credentials.values["Admin Password"]
+}
@@ -0,0 +1,4 @@
+import {
+  to = jiraassets_object.web01
+  id = "aql:objectType = Host AND ${provider::jiraassets::aql_eq("Host Name", "web01")}"
+}
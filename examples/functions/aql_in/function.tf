@@ -0,0 +1,6 @@
+list "jiraassets_object" "hosts" {
+  provider = jiraassets
+  config {
+    aql = "objectType = Host AND ${provider::jiraassets::aql_in("Status", ["Active", "In Repair"])}"
+  }
+}
@@ -0,0 +1,8 @@
+variable "host_name" {
+  type = string
+}
+
+output "query" {
+  # e.g. objectType = Host AND Name = "db \"primary\""
+  value = "objectType = Host AND Name = ${provider::jiraassets::aql_quote(var.host_name)}"
+}
@@ -0,0 +1,7 @@
+locals {
+  key = provider::jiraassets::parse_object_key(jiraassets_object.example_object.object_key)
+}
+
+output "object_number" {
+  value = local.key.number
+}
@@ -0,0 +1,8 @@
+list "jiraassets_object" "hosts" {
+  provider         = jiraassets
+  include_resource = true
+
+  config {
+    aql = "objectSchemaId = 1 AND objectType = Host"
+  }
+}
@@ -0,0 +1,8 @@
+# Objects can be imported by ID
+terraform import jiraassets_object.example_object 1234
+
+# by object key
+terraform import jiraassets_object.example_object key:ITSM-1234
+
+# or by an AQL query that matches exactly one object
+terraform import jiraassets_object.example_object 'aql:objectType = Host AND Name = "web01"'
@@ -0,0 +1,2 @@
+# Attachments are imported by object ID and attachment ID
+terraform import jiraassets_object_attachment.runbook 1234/5678
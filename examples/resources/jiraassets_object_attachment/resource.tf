@@ -0,0 +1,11 @@
+resource "jiraassets_object_attachment" "runbook" {
+  object_id = jiraassets_object.example_object.id
+  file      = "${path.module}/runbook.pdf"
+  comment   = "Runbook"
+}
+
+resource "jiraassets_object_attachment" "inventory" {
+  object_id = jiraassets_object.example_object.id
+  filename  = "inventory.json"
+  content   = jsonencode({ owner = "platform" })
+}
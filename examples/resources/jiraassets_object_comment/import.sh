@@ -0,0 +1,2 @@
+# Comments are imported by object ID and comment ID
+terraform import jiraassets_object_comment.patch_note 1234/5678
@@ -0,0 +1,4 @@
+resource "jiraassets_object_comment" "patch_note" {
+  object_id = jiraassets_object.example_object.id
+  body      = "Patched to 2.4 in CHG-42"
+}
@@ -0,0 +1,17 @@
+locals {
+  hosts = csvdecode(file("${path.module}/hosts.csv"))
+}
+
+resource "jiraassets_objects" "hosts" {
+  type          = "Host"
+  key_attribute = "Name"
+
+  objects = {
+    for host in local.hosts : host.name => {
+      "Hostname" = host.fqdn
+      "Status"   = "Enabled"
+    }
+  }
+
+  concurrency = 8
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// assetsErrorBody is the error document returned by the Assets API. errors
+// is keyed by attribute ID for rejected attribute values.
+type assetsErrorBody struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// apiErrorDiagnostics translates a failed API call into diagnostics. Errors
+// reported for an attribute ID known to index are attached to
+// attributes["<name>"], so the rejected value is shown next to its
+// configuration. index may be nil when the metadata is not available.
+func apiErrorDiagnostics(ctx context.Context, summary string, err error, response *models.ResponseScheme, index *metadataIndex) diag.Diagnostics {
+	return apiErrorDiagnosticsAt(ctx, summary, err, response, index, func(name string) path.Path {
+		return path.Root("attributes").AtMapKey(name)
+	}, nil)
+}
+
+// apiErrorDiagnosticsAt is apiErrorDiagnostics for attribute values that are
+// not configured in attributes, attributePath returns where the errors of an
+// attribute are attached. Assets may echo rejected values, secrets are
+// redacted from the diagnostics since log masking does not cover them.
+func apiErrorDiagnosticsAt(ctx context.Context, summary string, err error, response *models.ResponseScheme, index *metadataIndex, attributePath func(name string) path.Path, secrets []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	summary = redactSecrets(summary, secrets)
+	if response == nil {
+		diags.AddError(summary, redactSecrets(err.Error(), secrets))
+		return diags
+	}
+
+	tflog.Error(ctx, summary, map[string]interface{}{
+		"url":         response.Endpoint,
+		"method":      response.Method,
+		"status_code": response.Code,
+		"body":        response.Bytes.String(),
+	})
+
+	var body assetsErrorBody
+	if jsonErr := json.Unmarshal(response.Bytes.Bytes(), &body); jsonErr != nil || (len(body.ErrorMessages) == 0 && len(body.Errors) == 0) {
+		detail := fmt.Sprintf("%s %s returned %d: %s", response.Method, response.Endpoint, response.Code, err)
+		if raw := strings.TrimSpace(response.Bytes.String()); raw != "" {
+			detail += "\n\n" + raw
+		}
+		diags.AddError(summary, redactSecrets(detail, secrets))
+		return diags
+	}
+
+	var messages []string
+	messages = append(messages, body.ErrorMessages...)
+
+	ids := make([]string, 0, len(body.Errors))
+	for id := range body.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		message := body.Errors[id]
+		var attr *models.ObjectTypeAttributeScheme
+		if index != nil {
+			attr = index.attributesByID[id]
+		}
+		if attr == nil {
+			// not an attribute ID, e.g. a field of the object itself
+			messages = append(messages, fmt.Sprintf("%s: %s", id, message))
+			continue
+		}
+		diags.AddAttributeError(
+			attributePath(attr.Name),
+			summary,
+			redactSecrets(fmt.Sprintf("Assets rejected the value of attribute %q: %s", attr.Name, message), secrets),
+		)
+	}
+
+	if len(messages) > 0 {
+		diags.AddError(summary, redactSecrets(strings.Join(messages, "\n"), secrets))
+	}
+	return diags
+}
+
+// redactSecrets replaces every secret in text, longer ones first so a secret
+// containing another is replaced as a whole.
+func redactSecrets(text string, secrets []string) string {
+	sorted := slices.Clone(secrets)
+	slices.SortFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+	for _, secret := range sorted {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, "(sensitive value)")
+		}
+	}
+	return text
+}
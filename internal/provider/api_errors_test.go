@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testErrorResponse(code int, body string) *models.ResponseScheme {
+	response := &models.ResponseScheme{
+		Code:     code,
+		Endpoint: "https://api.example.com/jsm/assets/workspace/ws/v1/object/create",
+		Method:   http.MethodPost,
+	}
+	response.Bytes.WriteString(body)
+	return response
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	index := newMetadataIndex(nil, []*models.ObjectTypeAttributeScheme{
+		{ID: "11", Name: "Hostname", ObjectType: &models.ObjectTypeScheme{Name: "Host"}, Editable: true},
+	}, nil)
+
+	cases := map[string]struct {
+		response *models.ResponseScheme
+		index    *metadataIndex
+		want     []string
+		paths    []path.Path
+	}{
+		"no response": {
+			want: []string{"connection refused"},
+		},
+		"attribute error": {
+			response: testErrorResponse(400, `{"errorMessages":[],"errors":{"11":"Not a valid DNS name"}}`),
+			index:    index,
+			want:     []string{`Assets rejected the value of attribute "Hostname": Not a valid DNS name`},
+			paths:    []path.Path{path.Root("attributes").AtMapKey("Hostname")},
+		},
+		"unknown attribute and messages": {
+			response: testErrorResponse(400, `{"errorMessages":["Object is locked"],"errors":{"99":"Unknown attribute"}}`),
+			index:    index,
+			want:     []string{"Object is locked\n99: Unknown attribute"},
+		},
+		"attribute without metadata": {
+			response: testErrorResponse(400, `{"errorMessages":[],"errors":{"11":"Not a valid DNS name"}}`),
+			want:     []string{"11: Not a valid DNS name"},
+		},
+		"plain body": {
+			response: testErrorResponse(502, "Bad Gateway"),
+			want:     []string{"POST https://api.example.com/jsm/assets/workspace/ws/v1/object/create returned 502: connection refused\n\nBad Gateway"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := apiErrorDiagnostics(context.Background(), "Error during object creation", errors.New("connection refused"), tc.response, tc.index)
+			if len(diags) != len(tc.want) {
+				t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(tc.want), diags)
+			}
+			for i, d := range diags {
+				if d.Severity() != diag.SeverityError || d.Summary() != "Error during object creation" || d.Detail() != tc.want[i] {
+					t.Errorf("diagnostic %d = %s %q: %q", i, d.Severity(), d.Summary(), d.Detail())
+				}
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if i < len(tc.paths) {
+					if !ok || !withPath.Path().Equal(tc.paths[i]) {
+						t.Errorf("diagnostic %d is not attached to %s", i, tc.paths[i])
+					}
+				} else if ok {
+					t.Errorf("diagnostic %d is attached to %s", i, withPath.Path())
+				}
+			}
+		})
+	}
+}
+
+func TestObjectResourceAttributeErrors(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.rejected = map[string]string{"11": "Not a valid DNS name"}
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	_, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":     "web01",
+		"Hostname": "web 01",
+	}))
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("Create returned %v, want a single error", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("attributes").AtMapKey("Hostname")) {
+		t.Errorf("error %v is not attached to attributes[\"Hostname\"]", diags.Errors()[0])
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), "Not a valid DNS name") {
+		t.Errorf("error detail = %q", diags.Errors()[0].Detail())
+	}
+
+	fake.rejected = nil
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Name": "web01"}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	fake.rejected = map[string]string{"11": "Not a valid DNS name"}
+	plan := created
+	plan.Attributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Name": "web01", "Hostname": "web 01"}))
+	if _, diags := testResourceUpdate(t, r, created, plan); diags.ErrorsCount() != 1 {
+		t.Errorf("Update returned %v, want a single error", diags)
+	}
+}
+
+func TestObjectResourceSensitiveAttributeErrors(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.addAttribute(fake.objectType("1"), "14", "License Key", 0, false)
+	fake.addAttribute(fake.objectType("1"), "15", "Password", 0, false)
+	// Assets echoes the rejected values
+	fake.rejected = map[string]string{
+		"14": "AAAA-BBBB is not a valid license key",
+		"15": "hunter2 is too weak",
+	}
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	plan.SensitiveAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"License Key": "AAAA-BBBB"}))
+	plan.SensitiveAttributesWo = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Password": "hunter2"}))
+	plan.SensitiveAttributesWoVersion = types.Int64Value(1)
+	plan.WriteOnlyAttributeNames = types.ListUnknown(types.StringType)
+	_, diags := testResourceCreate(t, r, plan)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("Create returned %v, want two errors", diags)
+	}
+
+	want := []path.Path{
+		path.Root("sensitive_attributes").AtMapKey("License Key"),
+		path.Root("sensitive_attributes_wo").AtMapKey("Password"),
+	}
+	for i, d := range diags.Errors() {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(want[i]) {
+			t.Errorf("error %d %v is not attached to %s", i, d, want[i])
+		}
+		for _, secret := range []string{"AAAA-BBBB", "hunter2"} {
+			if strings.Contains(d.Summary(), secret) || strings.Contains(d.Detail(), secret) {
+				t.Errorf("error %d reveals %q: %s", i, secret, d.Detail())
+			}
+		}
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	got := redactSecrets("hunter2 and hunter22 are rejected", []string{"hunter2", "", "hunter22"})
+	if want := "(sensitive value) and (sensitive value) are rejected"; got != want {
+		t.Errorf("redactSecrets = %q, want %q", got, want)
+	}
+}
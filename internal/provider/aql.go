@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// aqlQuote returns value as a double quoted AQL string, escaping quotes and
+// backslashes so names with spaces or quotes can be used in a query.
+func aqlQuote(value string) string {
+	var b strings.Builder
+	b.Grow(len(value) + 2)
+	b.WriteByte('"')
+	for _, r := range value {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// filterObjects returns up to maxResults objects matching aql, without their
+// attributes, and the total number of matches.
+func filterObjects(ctx context.Context, client *assets.Client, workspaceId string, aql string, maxResults int) ([]*models.ObjectScheme, int, error) {
+	result, response, err := client.Object.Filter(ctx, workspaceId, aql, false, 0, maxResults)
+	if err != nil {
+		if response != nil {
+			return nil, 0, fmt.Errorf("unable to search objects with AQL %q: %w: %s", aql, err, response.Bytes.String())
+		}
+		return nil, 0, fmt.Errorf("unable to search objects with AQL %q: %w", aql, err)
+	}
+	return result.Values, max(result.Total, len(result.Values)), nil
+}
+
+// findSingleObject runs aql and returns the only object it matches. It is an
+// error if the query matches no object or more than one.
+func findSingleObject(ctx context.Context, client *assets.Client, workspaceId string, aql string) (*models.ObjectScheme, error) {
+	// two results are enough to tell a unique match from an ambiguous one
+	objects, total, err := filterObjects(ctx, client, workspaceId, aql, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case total == 0:
+		return nil, fmt.Errorf("no object matches AQL %q", aql)
+	case total > 1:
+		return nil, fmt.Errorf("AQL %q matches %d objects, expected exactly one", aql, total)
+	}
+	return objects[0], nil
+}
+
+// getObjectWithAttributes returns an object and its attributes with a single
+// AQL search by ID. It falls back to the object and attributes endpoints if
+// the search fails, e.g. because AQL is not available to the user, or does
+// not find the object yet, e.g. right after it was created. The response is
+// the one of the failed request, if any.
+func getObjectWithAttributes(ctx context.Context, client *assets.Client, workspaceId string, id string) (*models.ObjectScheme, []*models.ObjectAttributeScheme, *models.ResponseScheme, error) {
+	aql := "objectId = " + aqlQuote(id)
+	result, response, err := client.Object.Filter(ctx, workspaceId, aql, true, 0, 1)
+	switch {
+	case err != nil:
+		tflog.Debug(ctx, "Unable to read object with AQL, falling back to the object endpoints", map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		})
+	case len(result.Values) == 1 && result.Values[0].ID == id:
+		return result.Values[0], result.Values[0].Attributes, response, nil
+	}
+	if ctx.Err() != nil {
+		return nil, nil, response, ctx.Err()
+	}
+
+	object, response, err := client.Object.Get(ctx, workspaceId, id)
+	if err != nil {
+		return nil, nil, response, err
+	}
+	attrs, response, err := client.Object.Attributes(ctx, workspaceId, id)
+	if err != nil {
+		return nil, nil, response, err
+	}
+	return object, attrs, response, nil
+}
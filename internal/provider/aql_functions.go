@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &aqlQuoteFunction{}
+	_ function.Function = &aqlEqFunction{}
+	_ function.Function = &aqlInFunction{}
+)
+
+// NewAqlQuoteFunction is a helper function to simplify the provider implementation.
+func NewAqlQuoteFunction() function.Function {
+	return &aqlQuoteFunction{}
+}
+
+// aqlQuoteFunction quotes a value for use in an AQL query.
+type aqlQuoteFunction struct{}
+
+// Metadata returns the function name.
+func (f *aqlQuoteFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aql_quote"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *aqlQuoteFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Quote a value for AQL",
+		Description: "Returns the value as a double quoted AQL string, escaping quotes and backslashes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "The value to quote.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run quotes the value.
+func (f *aqlQuoteFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, aqlQuote(value))
+}
+
+// NewAqlEqFunction is a helper function to simplify the provider implementation.
+func NewAqlEqFunction() function.Function {
+	return &aqlEqFunction{}
+}
+
+// aqlEqFunction builds an AQL clause comparing an attribute with a value.
+type aqlEqFunction struct{}
+
+// Metadata returns the function name.
+func (f *aqlEqFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aql_eq"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *aqlEqFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an AQL equality clause",
+		Description: "Returns an AQL clause matching objects whose attribute equals the value, e.g. `\"Name\" = \"web01\"`. The attribute name and the value are both quoted.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "attribute",
+				Description: "The name of the attribute.",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "The value to compare with.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the clause.
+func (f *aqlEqFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var attribute, value string
+	resp.Error = req.Arguments.Get(ctx, &attribute, &value)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, aqlQuote(attribute)+" = "+aqlQuote(value))
+}
+
+// NewAqlInFunction is a helper function to simplify the provider implementation.
+func NewAqlInFunction() function.Function {
+	return &aqlInFunction{}
+}
+
+// aqlInFunction builds an AQL clause matching an attribute against a list of
+// values.
+type aqlInFunction struct{}
+
+// Metadata returns the function name.
+func (f *aqlInFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aql_in"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *aqlInFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an AQL IN clause",
+		Description: "Returns an AQL clause matching objects whose attribute equals one of the values, e.g. `\"Status\" IN (\"Active\", \"Retired\")`. The attribute name and the values are quoted.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "attribute",
+				Description: "The name of the attribute.",
+			},
+			function.ListParameter{
+				Name:        "values",
+				ElementType: types.StringType,
+				Description: "The values to compare with, at least one.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the clause.
+func (f *aqlInFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var attribute string
+	var values []string
+	resp.Error = req.Arguments.Get(ctx, &attribute, &values)
+	if resp.Error != nil {
+		return
+	}
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(1, "values must contain at least one value, AQL has no empty IN clause")
+		return
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, aqlQuote(value))
+	}
+	resp.Error = resp.Result.Set(ctx, aqlQuote(attribute)+" IN ("+strings.Join(quoted, ", ")+")")
+}
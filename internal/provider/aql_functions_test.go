@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testFunctionRun runs f with the arguments and returns its result, which
+// starts out as result.
+func testFunctionRun(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestAqlFunctions(t *testing.T) {
+	list := func(values ...string) attr.Value {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name string
+		f    function.Function
+		args []attr.Value
+		want string
+	}{
+		{"quote", NewAqlQuoteFunction(), []attr.Value{types.StringValue(`say "hi"`)}, `"say \"hi\""`},
+		{"eq", NewAqlEqFunction(), []attr.Value{types.StringValue("Host Name"), types.StringValue("web01")}, `"Host Name" = "web01"`},
+		{"in", NewAqlInFunction(), []attr.Value{types.StringValue("Status"), list("Active", `C:\`)}, `"Status" IN ("Active", "C:\\")`},
+		{"in single", NewAqlInFunction(), []attr.Value{types.StringValue("Status"), list("Active")}, `"Status" IN ("Active")`},
+	}
+	for _, test := range tests {
+		got, err := testFunctionRun(t, test.f, types.StringUnknown(), test.args...)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got.(types.String).ValueString() != test.want {
+			t.Errorf("%s = %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	if _, err := testFunctionRun(t, NewAqlInFunction(), types.StringUnknown(), types.StringValue("Status"), list()); err == nil {
+		t.Error("aql_in with no values returned no error")
+	}
+}
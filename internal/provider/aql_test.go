@@ -0,0 +1,24 @@
+package provider
+
+import "testing"
+
+func TestAqlQuote(t *testing.T) {
+	tests := map[string]string{
+		"web01":      `"web01"`,
+		"":           `""`,
+		"Web Server": `"Web Server"`,
+		`say "hi"`:   `"say \"hi\""`,
+		`C:\Windows`: `"C:\\Windows"`,
+		`trailing \`: `"trailing \\"`,
+		"ünïcode":    `"ünïcode"`,
+	}
+
+	for value, want := range tests {
+		if got := aqlQuote(value); got != want {
+			t.Errorf("aqlQuote(%q) = %s, want %s", value, got, want)
+		}
+		if got := unquoteFakeAQL(aqlQuote(value)); got != value {
+			t.Errorf("aqlQuote(%q) does not round trip, got %q", value, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// objectAttachment is an attachment of an object as returned by the Assets
+// attachments endpoints.
+type objectAttachment struct {
+	ID       json.Number `json:"id"`
+	Author   string      `json:"author,omitempty"`
+	MimeType string      `json:"mimeType,omitempty"`
+	Filename string      `json:"filename,omitempty"`
+	Filesize string      `json:"filesize,omitempty"`
+	Created  string      `json:"created,omitempty"`
+	Comment  string      `json:"comment,omitempty"`
+	URL      string      `json:"url,omitempty"`
+}
+
+// errObjectNotFound is returned when the object of an attachment or comment
+// does not exist anymore.
+var errObjectNotFound = errors.New("object not found")
+
+// attachmentContent returns the content configured with file, content or
+// content_base64 and the file name it is uploaded with.
+func attachmentContent(file types.String, content types.String, contentBase64 types.String, filename types.String) ([]byte, string, error) {
+	name := filename.ValueString()
+	switch {
+	case !file.IsNull():
+		data, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read file: %w", err)
+		}
+		if name == "" {
+			name = filepath.Base(file.ValueString())
+		}
+		return data, name, nil
+	case !content.IsNull():
+		return []byte(content.ValueString()), name, nil
+	case !contentBase64.IsNull():
+		data, err := base64.StdEncoding.DecodeString(contentBase64.ValueString())
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to decode content_base64: %w", err)
+		}
+		return data, name, nil
+	}
+	return nil, "", errors.New("one of file, content or content_base64 must be set")
+}
+
+func listObjectAttachments(ctx context.Context, client *assets.Client, workspaceId string, objectId string) ([]*objectAttachment, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/attachments/object/%v", workspaceId, objectId)
+	req, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var attachments []*objectAttachment
+	response, err := client.Call(req, &attachments)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, response, fmt.Errorf("%w: %s", errObjectNotFound, objectId)
+		}
+		return nil, response, err
+	}
+	return attachments, response, nil
+}
+
+func uploadObjectAttachment(ctx context.Context, client *assets.Client, workspaceId string, objectId string, filename string, content []byte, comment string) (*objectAttachment, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/attachments/object/%v", workspaceId, objectId)
+	fields := map[string]string{}
+	if comment != "" {
+		fields["encodedComment"] = base64.StdEncoding.EncodeToString([]byte(comment))
+	}
+	req, err := newMultipartRequest(ctx, client, endpoint, filename, content, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := new(objectAttachment)
+	response, err := client.Call(req, attachment)
+	if err != nil {
+		return nil, response, err
+	}
+	return attachment, response, nil
+}
+
+func deleteObjectAttachment(ctx context.Context, client *assets.Client, workspaceId string, id string) (*models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/attachments/%v", workspaceId, id)
+	req, err := client.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Call(req, nil)
+	// already gone
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return response, err
+	}
+	return response, nil
+}
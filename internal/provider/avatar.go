@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// avatarContent returns the image configured with avatar_file or
+// avatar_base64 and a file name for the upload. It returns nil when neither
+// is set.
+func avatarContent(avatarFile types.String, avatarBase64 types.String) ([]byte, string, error) {
+	switch {
+	case !avatarFile.IsNull() && avatarFile.ValueString() != "":
+		content, err := os.ReadFile(avatarFile.ValueString())
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read avatar_file: %w", err)
+		}
+		return content, filepath.Base(avatarFile.ValueString()), nil
+	case !avatarBase64.IsNull() && avatarBase64.ValueString() != "":
+		content, err := base64.StdEncoding.DecodeString(avatarBase64.ValueString())
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to decode avatar_base64: %w", err)
+		}
+		return content, "avatar", nil
+	}
+	return nil, "", nil
+}
+
+// contentHash identifies uploaded content, so a changed avatar or attachment
+// is uploaded again.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// uploadAvatar uploads an image to the Assets avatar endpoint and returns the
+// UUID to reference it from objects.
+func uploadAvatar(ctx context.Context, client *assets.Client, workspaceId string, filename string, content []byte) (string, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/avatar/upload", workspaceId)
+	req, err := newMultipartRequest(ctx, client, endpoint, filename, content, nil)
+	if err != nil {
+		return "", err
+	}
+
+	avatar := new(models.ObjectAvatarScheme)
+	response, err := client.Call(req, avatar)
+	if err != nil {
+		if response != nil {
+			return "", fmt.Errorf("unable to upload avatar to %s: %w: %s", response.Endpoint, err, response.Bytes.String())
+		}
+		return "", fmt.Errorf("unable to upload avatar: %w", err)
+	}
+	if avatar.AvatarUUID == "" {
+		return "", fmt.Errorf("avatar upload to %s returned no avatarUUID", response.Endpoint)
+	}
+	return avatar.AvatarUUID, nil
+}
+
+// newMultipartRequest builds a POST request uploading content as the form
+// file "file", with fields as additional form values.
+func newMultipartRequest(ctx context.Context, client *assets.Client, endpoint string, filename string, content []byte, fields map[string]string) (*http.Request, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	// the client only encodes JSON bodies, set the multipart body directly
+	req.Body = io.NopCloser(body)
+	req.ContentLength = int64(body.Len())
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "no-check")
+	return req, nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// objectComment is a comment of an object as returned by the Assets comment
+// endpoints.
+type objectComment struct {
+	ID       json.Number `json:"id"`
+	ObjectID json.Number `json:"objectId,omitempty"`
+	Comment  string      `json:"comment"`
+	Role     int64       `json:"role"`
+	Created  string      `json:"created,omitempty"`
+	Updated  string      `json:"updated,omitempty"`
+}
+
+func createObjectComment(ctx context.Context, client *assets.Client, workspaceId string, objectId string, body string, role int64) (*objectComment, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/comment/create", workspaceId)
+	payload := map[string]interface{}{
+		"objectId": objectId,
+		"comment":  body,
+		"role":     role,
+	}
+	req, err := client.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment := new(objectComment)
+	response, err := client.Call(req, comment)
+	if err != nil {
+		return nil, response, err
+	}
+	return comment, response, nil
+}
+
+func listObjectComments(ctx context.Context, client *assets.Client, workspaceId string, objectId string) ([]*objectComment, *models.ResponseScheme, error) {
+	endpoint := fmt.Sprintf("jsm/assets/workspace/%v/v1/comment/object/%v", workspaceId, objectId)
+	req, err := client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var comments []*objectComment
+	response, err := client.Call(req, &comments)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, response, fmt.Errorf("%w: %s", errObjectNotFound, objectId)
+		}
+		return nil, response, err
+	}
+	return comments, response, nil
+}
+
+// changeCommentBody appends the Terraform run context to the change_comment
+// of an object: what happened to the object, the changed attributes and the
+// HCP Terraform run, when the provider runs in one.
+func changeCommentBody(comment string, action string, changed []string) string {
+	var b strings.Builder
+	b.WriteString(comment)
+	b.WriteString("\n\nTerraform ")
+	b.WriteString(action)
+	b.WriteString(" this object.")
+	if len(changed) > 0 {
+		b.WriteString("\nChanged attributes: ")
+		b.WriteString(strings.Join(changed, ", "))
+	}
+	if workspace := os.Getenv("TFC_WORKSPACE_NAME"); workspace != "" {
+		b.WriteString("\nWorkspace: ")
+		b.WriteString(workspace)
+	}
+	if run := os.Getenv("TFC_RUN_ID"); run != "" {
+		b.WriteString("\nRun: ")
+		b.WriteString(run)
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// doneStatusColor is the color of statuses in the Jira "Done" status
+// category. The connected tickets of an object carry neither the status
+// category nor the resolution, so the color is the best guess at whether a
+// ticket is done unless the provider lists its closed_ticket_statuses.
+const doneStatusColor = "green"
+
+// ticketIsOpen reports whether a connected ticket is not done yet. When
+// closedStatuses is set, every ticket whose status name is not in it is
+// open, otherwise every ticket whose status is not green.
+func ticketIsOpen(ticket *models.TicketScheme, closedStatuses []string) bool {
+	if ticket.Status == nil {
+		return true
+	}
+	if len(closedStatuses) > 0 {
+		for _, status := range closedStatuses {
+			if strings.EqualFold(status, ticket.Status.Name) {
+				return false
+			}
+		}
+		return true
+	}
+	return ticket.Status.ColorName != doneStatusColor
+}
+
+// connectedTickets returns the Jira issues connected to an object.
+func connectedTickets(ctx context.Context, client *assets.Client, workspaceId string, objectId string) (*models.TicketPageScheme, *models.ResponseScheme, error) {
+	return client.Object.Relation(ctx, workspaceId, objectId)
+}
@@ -0,0 +1,937 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+const fakeWorkspaceId = "test-workspace"
+
+// fakeAssets is an in-memory implementation of the parts of the Assets REST
+// API used by the provider, served over httptest so the provider can be
+// exercised without network access.
+type fakeAssets struct {
+	*httptest.Server
+	t *testing.T
+
+	mu          sync.Mutex
+	schemas     map[string]*models.ObjectSchemaScheme
+	objectTypes map[string][]*models.ObjectTypeScheme
+	attributes  map[string][]*models.ObjectTypeAttributeScheme
+	statuses    map[string][]StatusTypeMetadata
+	objects     map[string]*models.ObjectScheme
+	avatars     map[string][]byte
+	attachments map[string][]*objectAttachment
+	contents    map[string][]byte
+	comments    map[string][]*objectComment
+	history     map[string][]*models.ObjectHistoryScheme
+	tickets     map[string][]*models.TicketScheme
+	nextId      int
+	clock       time.Time
+	requests    map[string]int
+
+	// latency delays every response, to exercise timeouts
+	latency time.Duration
+	// rejected maps attribute IDs to the error returned for any value set
+	// on them, to exercise API validation errors
+	rejected map[string]string
+	// aqlUnavailable fails every AQL search, to exercise the fallbacks to
+	// the object endpoints
+	aqlUnavailable bool
+	// attachmentPrefix is prepended to the name of uploaded attachments, to
+	// exercise files that Assets stores under another name
+	attachmentPrefix string
+}
+
+// newFakeAssets starts a fake Assets API holding two object schemas:
+//
+//	schema 1: "Host" (Name, Hostname, Status, Application) and "Application" (Name)
+//	schema 2: "Service" (Name)
+//
+// Every object type also has the system attributes Key, Created and Updated.
+func newFakeAssets(t *testing.T) *fakeAssets {
+	t.Helper()
+
+	f := &fakeAssets{
+		t:           t,
+		schemas:     map[string]*models.ObjectSchemaScheme{},
+		objectTypes: map[string][]*models.ObjectTypeScheme{},
+		attributes:  map[string][]*models.ObjectTypeAttributeScheme{},
+		statuses:    map[string][]StatusTypeMetadata{},
+		objects:     map[string]*models.ObjectScheme{},
+		avatars:     map[string][]byte{},
+		attachments: map[string][]*objectAttachment{},
+		contents:    map[string][]byte{},
+		comments:    map[string][]*objectComment{},
+		history:     map[string][]*models.ObjectHistoryScheme{},
+		tickets:     map[string][]*models.TicketScheme{},
+		nextId:      1000,
+		clock:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		requests:    map[string]int{},
+	}
+
+	f.addSchema("1", "ITSM")
+	host := f.addObjectType("1", "1", "Host")
+	application := f.addObjectType("1", "2", "Application")
+	f.addAttribute(host, "10", "Name", 0, true)
+	f.addAttribute(host, "11", "Hostname", 0, false)
+	f.addAttribute(host, "12", "Status", 7, false)
+	f.addAttribute(host, "13", "Application", 1, false)
+	f.addAttribute(application, "20", "Name", 0, true)
+	f.addStatus("1", "1", "Active")
+	f.addStatus("1", "2", "Retired")
+
+	f.addSchema("2", "SRV")
+	service := f.addObjectType("2", "3", "Service")
+	f.addAttribute(service, "30", "Name", 0, true)
+	f.addStatus("2", "3", "Running")
+
+	mux := http.NewServeMux()
+	base := "/jsm/assets/workspace/{workspace}/v1"
+	f.handle(mux, "GET "+base+"/objectschema/{id}", f.getObjectSchema)
+	f.handle(mux, "GET "+base+"/objectschema/{id}/objecttypes", f.getObjectTypes)
+	f.handle(mux, "GET "+base+"/objectschema/{id}/attributes", f.getSchemaAttributes)
+	f.handle(mux, "GET "+base+"/objecttype/{id}", f.getObjectType)
+	f.handle(mux, "GET "+base+"/config/statustype", f.getStatusTypes)
+	f.handle(mux, "POST "+base+"/avatar/upload", f.uploadAvatar)
+	f.handle(mux, "POST "+base+"/object/create", f.createObject)
+	f.handle(mux, "POST "+base+"/object/aql", f.filterObjects)
+	f.handle(mux, "GET "+base+"/object/{id}", f.getObject)
+	f.handle(mux, "PUT "+base+"/object/{id}", f.updateObject)
+	f.handle(mux, "DELETE "+base+"/object/{id}", f.deleteObject)
+	f.handle(mux, "GET "+base+"/object/{id}/attributes", f.getObjectAttributes)
+	f.handle(mux, "GET "+base+"/object/{id}/history", f.getObjectHistory)
+	f.handle(mux, "GET "+base+"/objectconnectedtickets/{id}/tickets", f.getConnectedTickets)
+	f.handle(mux, "GET "+base+"/attachments/object/{id}", f.getAttachments)
+	f.handle(mux, "POST "+base+"/attachments/object/{id}", f.uploadAttachment)
+	f.handle(mux, "DELETE "+base+"/attachments/{id}", f.deleteAttachment)
+	f.handle(mux, "POST "+base+"/comment/create", f.createComment)
+	f.handle(mux, "GET "+base+"/comment/object/{id}", f.getComments)
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAssets) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if f.latency > 0 {
+			time.Sleep(f.latency)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		f.requests[pattern]++
+		if r.PathValue("workspace") != fakeWorkspaceId {
+			f.writeError(w, http.StatusNotFound, "unknown workspace")
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// requestCount returns how often the route ending in suffix, e.g.
+// "GET /objectschema/{id}/attributes", was called.
+func (f *fakeAssets) requestCount(method string, suffix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for pattern, n := range f.requests {
+		if strings.HasPrefix(pattern, method+" ") && strings.HasSuffix(pattern, suffix) {
+			count += n
+		}
+	}
+	return count
+}
+
+// attributeValues returns the values stored for the named attribute of an
+// object.
+func (f *fakeAssets) attributeValues(objectId string, name string) []*models.ObjectTypeAssetAttributeValueScheme {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	object, ok := f.objects[objectId]
+	if !ok {
+		return nil
+	}
+	for _, attr := range object.Attributes {
+		if attr.ObjectTypeAttribute != nil && attr.ObjectTypeAttribute.Name == name {
+			return attr.ObjectAttributeValues
+		}
+	}
+	return nil
+}
+
+func (f *fakeAssets) addSchema(id string, key string) {
+	f.schemas[id] = &models.ObjectSchemaScheme{
+		WorkspaceId:     fakeWorkspaceId,
+		GlobalId:        fakeWorkspaceId + ":" + id,
+		Id:              id,
+		Name:            key + " schema",
+		ObjectSchemaKey: key,
+		Status:          "Ok",
+		Created:         f.timestamp(),
+		Updated:         f.timestamp(),
+		CanManage:       true,
+	}
+}
+
+func (f *fakeAssets) addObjectType(schemaId string, id string, name string) *models.ObjectTypeScheme {
+	objectType := &models.ObjectTypeScheme{
+		WorkspaceId:    fakeWorkspaceId,
+		GlobalId:       fakeWorkspaceId + ":" + id,
+		Id:             id,
+		Name:           name,
+		ObjectSchemaId: schemaId,
+	}
+	f.objectTypes[schemaId] = append(f.objectTypes[schemaId], objectType)
+	f.schemas[schemaId].ObjectTypeCount++
+
+	// like the API, every object type has its own read only system
+	// attributes, which are not value editable
+	for i, name := range []string{"Key", "Created", "Updated"} {
+		attr := f.addAttribute(objectType, fmt.Sprintf("9%s%d", id, i), name, 0, false)
+		attr.System = true
+		attr.Editable = false
+	}
+	return objectType
+}
+
+func (f *fakeAssets) addAttribute(objectType *models.ObjectTypeScheme, id string, name string, attrType int, label bool) *models.ObjectTypeAttributeScheme {
+	attr := &models.ObjectTypeAttributeScheme{
+		WorkspaceId: fakeWorkspaceId,
+		GlobalId:    fakeWorkspaceId + ":" + id,
+		ID:          id,
+		ObjectType:  objectType,
+		Name:        name,
+		Label:       label,
+		Type:        attrType,
+		Editable:    true,
+	}
+	schemaId := objectType.ObjectSchemaId
+	f.attributes[schemaId] = append(f.attributes[schemaId], attr)
+	return attr
+}
+
+func (f *fakeAssets) addStatus(schemaId string, id string, name string) {
+	f.statuses[schemaId] = append(f.statuses[schemaId], StatusTypeMetadata{
+		ID:             id,
+		Name:           name,
+		ObjectSchemaId: schemaId,
+	})
+}
+
+// timestamp returns a new, strictly increasing timestamp.
+func (f *fakeAssets) timestamp() string {
+	f.clock = f.clock.Add(time.Second)
+	return f.clock.Format("2006-01-02T15:04:05.000Z")
+}
+
+func (f *fakeAssets) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("fake assets: encoding response: %s", err)
+	}
+}
+
+func (f *fakeAssets) writeError(w http.ResponseWriter, status int, message string) {
+	f.writeJSON(w, status, map[string]interface{}{
+		"errorMessages": []string{message},
+		"errors":        map[string]string{},
+	})
+}
+
+func (f *fakeAssets) getObjectSchema(w http.ResponseWriter, r *http.Request) {
+	schema, ok := f.schemas[r.PathValue("id")]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "object schema not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, schema)
+}
+
+func (f *fakeAssets) getObjectTypes(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.schemas[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object schema not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, f.objectTypes[r.PathValue("id")])
+}
+
+func (f *fakeAssets) getObjectType(w http.ResponseWriter, r *http.Request) {
+	objectType := f.objectType(r.PathValue("id"))
+	if objectType == nil {
+		f.writeError(w, http.StatusNotFound, "object type not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, objectType)
+}
+
+func (f *fakeAssets) getSchemaAttributes(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.schemas[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object schema not found")
+		return
+	}
+	attributes := f.attributes[r.PathValue("id")]
+	if r.URL.Query().Get("onlyValueEditable") == "true" {
+		attributes = slices.DeleteFunc(slices.Clone(attributes), func(attr *models.ObjectTypeAttributeScheme) bool {
+			return !attr.Editable
+		})
+	}
+	f.writeJSON(w, http.StatusOK, attributes)
+}
+
+func (f *fakeAssets) getStatusTypes(w http.ResponseWriter, r *http.Request) {
+	statuses := f.statuses[r.URL.Query().Get("objectSchemaId")]
+	if statuses == nil {
+		statuses = []StatusTypeMetadata{}
+	}
+	f.writeJSON(w, http.StatusOK, statuses)
+}
+
+// objectType returns the object type with the given ID in any schema.
+func (f *fakeAssets) objectType(id string) *models.ObjectTypeScheme {
+	for _, objectTypes := range f.objectTypes {
+		for _, objectType := range objectTypes {
+			if objectType.Id == id {
+				return objectType
+			}
+		}
+	}
+	return nil
+}
+
+// systemAttribute returns the system attribute of an object type with the
+// given name.
+func (f *fakeAssets) systemAttribute(objectType *models.ObjectTypeScheme, name string) *models.ObjectTypeAttributeScheme {
+	for _, attr := range f.attributes[objectType.ObjectSchemaId] {
+		if attr.System && attr.ObjectType.Id == objectType.Id && attr.Name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+// attribute returns the attribute definition with the given ID in any schema.
+func (f *fakeAssets) attribute(id string) *models.ObjectTypeAttributeScheme {
+	for _, attributes := range f.attributes {
+		for _, attr := range attributes {
+			if attr.ID == id {
+				return attr
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeAssets) status(schemaId string, id string) (StatusTypeMetadata, bool) {
+	for _, status := range f.statuses[schemaId] {
+		if status.ID == id {
+			return status, true
+		}
+	}
+	return StatusTypeMetadata{}, false
+}
+
+// applyPayload sets the attributes of the payload on the object, the way
+// the API does a partial update. It returns the Assets error map keyed by
+// attribute ID for rejected values.
+func (f *fakeAssets) applyPayload(object *models.ObjectScheme, payload *models.ObjectPayloadScheme) map[string]string {
+	errs := map[string]string{}
+	for _, payloadAttr := range payload.Attributes {
+		def := f.attribute(payloadAttr.ObjectTypeAttributeID)
+		if def == nil || def.ObjectType.Id != object.ObjectType.Id {
+			errs[payloadAttr.ObjectTypeAttributeID] = "Unknown attribute for object type " + object.ObjectType.Name
+			continue
+		}
+		if message, ok := f.rejected[def.ID]; ok {
+			errs[def.ID] = message
+			continue
+		}
+
+		var values []*models.ObjectTypeAssetAttributeValueScheme
+		for _, payloadValue := range payloadAttr.ObjectAttributeValues {
+			value := &models.ObjectTypeAssetAttributeValueScheme{
+				Value:        payloadValue.Value,
+				DisplayValue: payloadValue.Value,
+				SearchValue:  payloadValue.Value,
+			}
+			if def.Type == 7 {
+				status, ok := f.status(object.ObjectType.ObjectSchemaId, payloadValue.Value)
+				if !ok {
+					errs[def.ID] = "Status " + payloadValue.Value + " does not exist"
+					continue
+				}
+				value = &models.ObjectTypeAssetAttributeValueScheme{
+					DisplayValue: status.Name,
+					SearchValue:  status.ID,
+					Status: &models.ObjectTypeAssetAttributeStatusScheme{
+						ID:   status.ID,
+						Name: status.Name,
+					},
+				}
+			}
+			values = append(values, value)
+		}
+
+		setFakeAttribute(object, def, values)
+		if def.Label && len(values) > 0 {
+			object.Label = values[0].DisplayValue
+		}
+	}
+	return errs
+}
+
+func setFakeAttribute(object *models.ObjectScheme, def *models.ObjectTypeAttributeScheme, values []*models.ObjectTypeAssetAttributeValueScheme) {
+	for _, attr := range object.Attributes {
+		if attr.ObjectTypeAttributeId == def.ID {
+			attr.ObjectAttributeValues = values
+			return
+		}
+	}
+	object.Attributes = append(object.Attributes, &models.ObjectAttributeScheme{
+		WorkspaceId:           fakeWorkspaceId,
+		ID:                    def.ID + "-" + object.ID,
+		ObjectTypeAttribute:   def,
+		ObjectTypeAttributeId: def.ID,
+		ObjectAttributeValues: values,
+	})
+}
+
+func (f *fakeAssets) uploadAvatar(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, "missing file: "+err.Error())
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	uuid := fmt.Sprintf("avatar-%d", len(f.avatars)+1)
+	f.avatars[uuid] = content
+	f.writeJSON(w, http.StatusOK, &models.ObjectAvatarScheme{
+		WorkspaceId: fakeWorkspaceId,
+		AvatarUUID:  uuid,
+	})
+}
+
+func (f *fakeAssets) getAttachments(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.objects[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	attachments := f.attachments[r.PathValue("id")]
+	if attachments == nil {
+		attachments = []*objectAttachment{}
+	}
+	f.writeJSON(w, http.StatusOK, attachments)
+}
+
+func (f *fakeAssets) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	objectId := r.PathValue("id")
+	if _, ok := f.objects[objectId]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, "missing file: "+err.Error())
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	comment, err := base64.StdEncoding.DecodeString(r.FormValue("encodedComment"))
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, "invalid encodedComment: "+err.Error())
+		return
+	}
+
+	f.nextId++
+	id := strconv.Itoa(f.nextId)
+	attachment := &objectAttachment{
+		ID:       json.Number(id),
+		Author:   "fake",
+		MimeType: "application/octet-stream",
+		Filename: f.attachmentPrefix + header.Filename,
+		Filesize: fmt.Sprintf("%d B", len(content)),
+		Created:  f.timestamp(),
+		Comment:  string(comment),
+		URL:      f.URL + "/attachments/" + id,
+	}
+	f.attachments[objectId] = append(f.attachments[objectId], attachment)
+	f.contents[id] = content
+	f.writeJSON(w, http.StatusOK, attachment)
+}
+
+func (f *fakeAssets) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	for objectId, attachments := range f.attachments {
+		for i, attachment := range attachments {
+			if attachment.ID.String() == r.PathValue("id") {
+				f.attachments[objectId] = append(attachments[:i:i], attachments[i+1:]...)
+				delete(f.contents, r.PathValue("id"))
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+	}
+	f.writeError(w, http.StatusNotFound, "attachment not found")
+}
+
+func (f *fakeAssets) createComment(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ObjectId string `json:"objectId"`
+		Comment  string `json:"comment"`
+		Role     int64  `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return
+	}
+	if _, ok := f.objects[payload.ObjectId]; !ok {
+		f.writeError(w, http.StatusBadRequest, "object "+payload.ObjectId+" does not exist")
+		return
+	}
+	if payload.Comment == "" {
+		f.writeError(w, http.StatusBadRequest, "comment is empty")
+		return
+	}
+
+	f.nextId++
+	created := f.timestamp()
+	comment := &objectComment{
+		ID:       json.Number(strconv.Itoa(f.nextId)),
+		ObjectID: json.Number(payload.ObjectId),
+		Comment:  payload.Comment,
+		Role:     payload.Role,
+		Created:  created,
+		Updated:  created,
+	}
+	f.comments[payload.ObjectId] = append(f.comments[payload.ObjectId], comment)
+	f.writeJSON(w, http.StatusCreated, comment)
+}
+
+func (f *fakeAssets) getComments(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.objects[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	comments := f.comments[r.PathValue("id")]
+	if comments == nil {
+		comments = []*objectComment{}
+	}
+	f.writeJSON(w, http.StatusOK, comments)
+}
+
+func (f *fakeAssets) decodePayload(w http.ResponseWriter, r *http.Request) (*models.ObjectPayloadScheme, bool) {
+	payload := new(models.ObjectPayloadScheme)
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		f.writeError(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return nil, false
+	}
+	return payload, true
+}
+
+// putObject validates and stores a copy of object with the payload applied.
+func (f *fakeAssets) putObject(w http.ResponseWriter, object *models.ObjectScheme, payload *models.ObjectPayloadScheme) {
+	if errs := f.applyPayload(object, payload); len(errs) > 0 {
+		f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"errorMessages": []string{},
+			"errors":        errs,
+		})
+		return
+	}
+	if payload.AvatarUUID != "" {
+		if _, ok := f.avatars[payload.AvatarUUID]; !ok {
+			f.writeError(w, http.StatusBadRequest, "avatar "+payload.AvatarUUID+" does not exist")
+			return
+		}
+		object.Avatar = &models.ObjectAvatarScheme{AvatarUUID: payload.AvatarUUID}
+	} else {
+		object.Avatar = nil
+	}
+	object.HasAvatar = payload.HasAvatar
+	object.Updated = f.timestamp()
+	setFakeAttribute(object, f.systemAttribute(object.ObjectType, "Key"), []*models.ObjectTypeAssetAttributeValueScheme{{Value: object.ObjectKey, DisplayValue: object.ObjectKey}})
+	setFakeAttribute(object, f.systemAttribute(object.ObjectType, "Created"), []*models.ObjectTypeAssetAttributeValueScheme{{Value: object.Created, DisplayValue: object.Created}})
+	setFakeAttribute(object, f.systemAttribute(object.ObjectType, "Updated"), []*models.ObjectTypeAssetAttributeValueScheme{{Value: object.Updated, DisplayValue: object.Updated}})
+	f.objects[object.ID] = object
+	f.writeJSON(w, http.StatusOK, withoutAttributes(object))
+}
+
+func (f *fakeAssets) createObject(w http.ResponseWriter, r *http.Request) {
+	payload, ok := f.decodePayload(w, r)
+	if !ok {
+		return
+	}
+	objectType := f.objectType(payload.ObjectTypeID)
+	if objectType == nil {
+		f.writeError(w, http.StatusBadRequest, "Object type "+payload.ObjectTypeID+" does not exist")
+		return
+	}
+
+	f.nextId++
+	id := strconv.Itoa(f.nextId)
+	object := &models.ObjectScheme{
+		WorkspaceId: fakeWorkspaceId,
+		GlobalId:    fakeWorkspaceId + ":" + id,
+		ID:          id,
+		ObjectKey:   f.schemas[objectType.ObjectSchemaId].ObjectSchemaKey + "-" + id,
+		ObjectType:  objectType,
+		Created:     f.timestamp(),
+	}
+	f.putObject(w, object, payload)
+}
+
+// withoutAttributes returns a copy of the object the way the object
+// endpoints return it, which is without attribute values.
+func withoutAttributes(object *models.ObjectScheme) *models.ObjectScheme {
+	shallow := *object
+	shallow.Attributes = nil
+	return &shallow
+}
+
+// copyObject deep copies the mutable parts of an object.
+func copyObject(object *models.ObjectScheme) *models.ObjectScheme {
+	copied := *object
+	copied.Attributes = nil
+	for _, attr := range object.Attributes {
+		a := *attr
+		copied.Attributes = append(copied.Attributes, &a)
+	}
+	return &copied
+}
+
+func (f *fakeAssets) getObject(w http.ResponseWriter, r *http.Request) {
+	object, ok := f.objects[r.PathValue("id")]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, withoutAttributes(object))
+}
+
+func (f *fakeAssets) updateObject(w http.ResponseWriter, r *http.Request) {
+	object, ok := f.objects[r.PathValue("id")]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	payload, ok := f.decodePayload(w, r)
+	if !ok {
+		return
+	}
+	f.putObject(w, copyObject(object), payload)
+}
+
+func (f *fakeAssets) deleteObject(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.objects[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	delete(f.objects, r.PathValue("id"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeAssets) getObjectAttributes(w http.ResponseWriter, r *http.Request) {
+	object, ok := f.objects[r.PathValue("id")]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, object.Attributes)
+}
+
+// addHistory records a history entry of an object, entries are kept oldest
+// first.
+func (f *fakeAssets) addHistory(objectId string, actor string, created string, attribute string, oldValue string, newValue string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextId++
+	f.history[objectId] = append(f.history[objectId], &models.ObjectHistoryScheme{
+		ID:                strconv.Itoa(f.nextId),
+		Actor:             &models.ObjectHistoryActorScheme{DisplayName: actor, Key: strings.ToLower(actor)},
+		AffectedAttribute: attribute,
+		OldValue:          oldValue,
+		NewValue:          newValue,
+		Type:              2,
+		Created:           created,
+		ObjectID:          objectId,
+	})
+}
+
+func (f *fakeAssets) getObjectHistory(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.objects[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	history := slices.Clone(f.history[r.PathValue("id")])
+	if history == nil {
+		history = []*models.ObjectHistoryScheme{}
+	}
+	// newest first unless asc is set, like the API
+	if r.URL.Query().Get("asc") != "true" {
+		slices.Reverse(history)
+	}
+	f.writeJSON(w, http.StatusOK, history)
+}
+
+// addTicket connects a Jira issue in the given status to an object.
+func (f *fakeAssets) addTicket(objectId string, key string, status string, color string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextId++
+	f.tickets[objectId] = append(f.tickets[objectId], &models.TicketScheme{
+		WorkspaceId: fakeWorkspaceId,
+		Id:          strconv.Itoa(f.nextId),
+		Key:         key,
+		Title:       "Ticket " + key,
+		Created:     f.timestamp(),
+		Updated:     f.timestamp(),
+		Status:      &models.TicketStatusScheme{Name: status, ColorName: color},
+		Type:        &models.TicketTypeScheme{Name: "Change"},
+		Priority:    &models.TicketPriorityScheme{Name: "Medium"},
+	})
+}
+
+func (f *fakeAssets) getConnectedTickets(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.objects[r.PathValue("id")]; !ok {
+		f.writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	f.writeJSON(w, http.StatusOK, &models.TicketPageScheme{
+		Tickets:         f.tickets[r.PathValue("id")],
+		AllTicketsQuery: "issueFunction in assetsObject(\"objectId = " + r.PathValue("id") + "\")",
+	})
+}
+
+func (f *fakeAssets) filterObjects(w http.ResponseWriter, r *http.Request) {
+	if f.aqlUnavailable {
+		f.writeError(w, http.StatusForbidden, "AQL is not available")
+		return
+	}
+	var body struct {
+		QlQuery string `json:"qlQuery"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.writeError(w, http.StatusBadRequest, "invalid payload: "+err.Error())
+		return
+	}
+	clauses, err := parseFakeAQL(body.QlQuery)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, "AQL: "+err.Error())
+		return
+	}
+
+	var matches []*models.ObjectScheme
+	for _, object := range f.objects {
+		if clauses.match(object) {
+			matches = append(matches, object)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, _ := strconv.Atoi(matches[i].ID)
+		b, _ := strconv.Atoi(matches[j].ID)
+		return a < b
+	})
+
+	query := r.URL.Query()
+	startAt, _ := strconv.Atoi(query.Get("startAt"))
+	maxResults, err := strconv.Atoi(query.Get("maxResults"))
+	if err != nil || maxResults <= 0 {
+		maxResults = 25
+	}
+	includeAttributes := query.Get("includeAttributes") != "false"
+
+	result := &models.ObjectListResultScheme{
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		Total:      len(matches),
+		IsLast:     startAt+maxResults >= len(matches),
+	}
+	for i := startAt; i < len(matches) && i < startAt+maxResults; i++ {
+		object := matches[i]
+		if includeAttributes {
+			// like the API, AQL results only reference attribute types by ID
+			object = copyObject(object)
+			for _, attr := range object.Attributes {
+				attr.ObjectTypeAttribute = nil
+			}
+		} else {
+			object = withoutAttributes(object)
+		}
+		result.Values = append(result.Values, object)
+	}
+	f.writeJSON(w, http.StatusOK, result)
+}
+
+// fakeAQLClause is a single "lhs = value" or "lhs IN (values)" condition.
+type fakeAQLClause struct {
+	field  string
+	values []string
+}
+
+// fakeAQL is a conjunction of clauses, the subset of AQL the provider uses.
+type fakeAQL []fakeAQLClause
+
+func (q fakeAQL) match(object *models.ObjectScheme) bool {
+	for _, clause := range q {
+		actual := fakeFieldValues(object, clause.field)
+		found := false
+		for _, want := range clause.values {
+			for _, got := range actual {
+				if strings.EqualFold(got, want) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func fakeFieldValues(object *models.ObjectScheme, field string) []string {
+	switch strings.ToLower(field) {
+	case "objectid":
+		return []string{object.ID}
+	case "key", "objectkey":
+		return []string{object.ObjectKey}
+	case "label":
+		return []string{object.Label}
+	case "objecttype":
+		return []string{object.ObjectType.Name}
+	case "objecttypeid":
+		return []string{object.ObjectType.Id}
+	case "objectschemaid":
+		return []string{object.ObjectType.ObjectSchemaId}
+	}
+	var values []string
+	for _, attr := range object.Attributes {
+		if attr.ObjectTypeAttribute == nil || !strings.EqualFold(attr.ObjectTypeAttribute.Name, field) {
+			continue
+		}
+		for _, value := range attr.ObjectAttributeValues {
+			values = append(values, value.DisplayValue)
+		}
+	}
+	return values
+}
+
+// parseFakeAQL parses clauses of the form `field = value` and
+// `field IN (value, ...)` joined by AND, with optionally quoted operands.
+func parseFakeAQL(aql string) (fakeAQL, error) {
+	tokens, err := tokenizeFakeAQL(aql)
+	if err != nil {
+		return nil, err
+	}
+
+	var query fakeAQL
+	for i := 0; i < len(tokens); {
+		if len(query) > 0 {
+			if !strings.EqualFold(tokens[i], "AND") {
+				return nil, fmt.Errorf("expected AND, got %q", tokens[i])
+			}
+			i++
+		}
+		if i+2 >= len(tokens) {
+			return nil, fmt.Errorf("incomplete clause in %q", aql)
+		}
+		clause := fakeAQLClause{field: unquoteFakeAQL(tokens[i])}
+		switch {
+		case tokens[i+1] == "=":
+			clause.values = []string{unquoteFakeAQL(tokens[i+2])}
+			i += 3
+		case strings.EqualFold(tokens[i+1], "IN") && tokens[i+2] == "(":
+			i += 3
+			for ; i < len(tokens) && tokens[i] != ")"; i++ {
+				if tokens[i] != "," {
+					clause.values = append(clause.values, unquoteFakeAQL(tokens[i]))
+				}
+			}
+			if i == len(tokens) {
+				return nil, fmt.Errorf("unterminated IN list in %q", aql)
+			}
+			i++
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", tokens[i+1])
+		}
+		query = append(query, clause)
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return query, nil
+}
+
+func tokenizeFakeAQL(aql string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(aql); {
+		c := aql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '=' || c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(aql) && aql[j] != '"'; j++ {
+				if aql[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(aql) {
+				return nil, fmt.Errorf("unterminated string in %q", aql)
+			}
+			tokens = append(tokens, aql[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(aql) && !strings.ContainsRune(" \t\n=(),\"", rune(aql[j])); j++ {
+			}
+			tokens = append(tokens, aql[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func unquoteFakeAQL(token string) string {
+	if len(token) < 2 || token[0] != '"' {
+		return token
+	}
+	var b strings.Builder
+	for i := 1; i < len(token)-1; i++ {
+		if token[i] == '\\' && i+1 < len(token)-1 {
+			i++
+		}
+		b.WriteByte(token[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metadataRefreshInterval is the minimum time between two loads of the
+// schema metadata, reloads triggered by lookup misses wait for it to pass.
+const metadataRefreshInterval = 5 * time.Second
+
+// schemaMetadataRegistry hands out the metadata cache of every object schema
+// used through a provider instance, creating them on first use.
+type schemaMetadataRegistry struct {
+	client          *assets.Client
+	workspaceId     string
+	diskCache       *metadataDiskCache
+	refreshInterval time.Duration
+
+	mu      sync.Mutex
+	schemas map[string]*schemaMetadata
+}
+
+func newSchemaMetadataRegistry(client *assets.Client, workspaceId string, diskCache *metadataDiskCache) *schemaMetadataRegistry {
+	return &schemaMetadataRegistry{
+		client:          client,
+		workspaceId:     workspaceId,
+		diskCache:       diskCache,
+		refreshInterval: metadataRefreshInterval,
+		schemas:         map[string]*schemaMetadata{},
+	}
+}
+
+// forSchema returns the metadata cache of an object schema. The metadata
+// itself is only fetched once a lookup needs it.
+func (r *schemaMetadataRegistry) forSchema(schemaId string) *schemaMetadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metadata, ok := r.schemas[schemaId]
+	if !ok {
+		metadata = newSchemaMetadata(r.client, r.workspaceId, schemaId, r.diskCache)
+		metadata.refreshInterval = r.refreshInterval
+		r.schemas[schemaId] = metadata
+	}
+	return metadata
+}
+
+// schemaMetadata lazily loads and caches the object types, attributes and
+// status types of an object schema. It is shared by every resource and data
+// source of a provider instance, so it must be safe for concurrent use.
+type schemaMetadata struct {
+	client          *assets.Client
+	workspaceId     string
+	schemaId        string
+	refreshInterval time.Duration
+	diskCache       *metadataDiskCache
+
+	// loadMu serializes requests to the API, mu guards the cached values.
+	loadMu     sync.Mutex
+	mu         sync.Mutex
+	generation uint64
+	loadedAt   time.Time
+	index      *metadataIndex
+}
+
+func newSchemaMetadata(client *assets.Client, workspaceId string, schemaId string, diskCache *metadataDiskCache) *schemaMetadata {
+	return &schemaMetadata{
+		client:          client,
+		workspaceId:     workspaceId,
+		schemaId:        schemaId,
+		refreshInterval: metadataRefreshInterval,
+		diskCache:       diskCache,
+	}
+}
+
+// load fetches the schema metadata on first use. Concurrent callers block
+// until the first load finishes, a failed load is retried by the next caller.
+func (m *schemaMetadata) load(ctx context.Context) error {
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	if _, generation := m.snapshot(); generation > 0 {
+		return nil
+	}
+	return m.fetch(ctx, true)
+}
+
+// refresh reloads the schema metadata after a lookup miss on the given
+// generation. Callers that missed on the same generation share a single
+// reload, and reloads are spaced at least refreshInterval apart.
+func (m *schemaMetadata) refresh(ctx context.Context, generation uint64) error {
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	m.mu.Lock()
+	current, loadedAt := m.generation, m.loadedAt
+	m.mu.Unlock()
+
+	if current != generation {
+		return nil
+	}
+
+	if wait := m.refreshInterval - time.Since(loadedAt); wait > 0 {
+		tflog.Debug(ctx, "Delaying object schema metadata reload", map[string]interface{}{
+			"object_schema_id": m.schemaId,
+			"delay":            wait.String(),
+		})
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	// a miss means the cached copy is stale, so always go to the API
+	return m.fetch(ctx, false)
+}
+
+// fetch downloads the schema metadata, or reads it from the disk cache when
+// useDiskCache is set and the cached copy is still valid. m.loadMu must be
+// held by the caller.
+func (m *schemaMetadata) fetch(ctx context.Context, useDiskCache bool) error {
+	if m.schemaId == "" {
+		return fmt.Errorf("object_schema_id is not set, set it on the resource, in the provider configuration or use the JIRAASSETS_OBJECTSCHEMA_ID environment variable")
+	}
+
+	// the schema's updated timestamp validates entries of the disk cache
+	schemaUpdated := ""
+	if m.diskCache != nil {
+		schema, response, err := m.client.ObjectSchema.Get(ctx, m.workspaceId, m.schemaId)
+		if err != nil {
+			if response != nil {
+				return fmt.Errorf("unable to read object schema from %s: %w: %s", response.Endpoint, err, response.Bytes.String())
+			}
+			return fmt.Errorf("unable to read object schema: %w", err)
+		}
+		schemaUpdated = schema.Updated
+
+		if useDiskCache {
+			if entry, ok := m.diskCache.read(m.workspaceId, m.schemaId, schemaUpdated); ok {
+				tflog.Debug(ctx, "Using object schema metadata from disk cache", map[string]interface{}{
+					"object_schema_id": m.schemaId,
+					"fetched_at":       entry.FetchedAt.String(),
+				})
+				m.store(entry.ObjectTypes, entry.ObjectAttributes, entry.StatusTypes)
+				return nil
+			}
+		}
+	}
+
+	tflog.Debug(ctx, "Loading object schema metadata", map[string]interface{}{
+		"object_schema_id": m.schemaId,
+	})
+
+	objectTypes, err := getObjectSchemaObjectTypes(ctx, m.client, m.workspaceId, m.schemaId)
+	if err != nil {
+		return err
+	}
+	objectAttributes, err := getObjectSchemaAttributes(ctx, m.client, m.workspaceId, m.schemaId)
+	if err != nil {
+		return err
+	}
+	statusTypes, err := getConfigStatusType(ctx, m.client, m.workspaceId, m.schemaId)
+	if err != nil {
+		return err
+	}
+
+	m.store(objectTypes, objectAttributes, statusTypes)
+
+	if m.diskCache != nil {
+		err := m.diskCache.write(&metadataCacheEntry{
+			WorkspaceId:      m.workspaceId,
+			ObjectSchemaId:   m.schemaId,
+			SchemaUpdated:    schemaUpdated,
+			FetchedAt:        time.Now(),
+			ObjectTypes:      objectTypes,
+			ObjectAttributes: objectAttributes,
+			StatusTypes:      statusTypes,
+		})
+		// a failed write only costs the next run a download
+		if err != nil {
+			tflog.Warn(ctx, "Unable to write object schema metadata to disk cache", map[string]interface{}{
+				"object_schema_id": m.schemaId,
+				"error":            err.Error(),
+			})
+		}
+	}
+	return nil
+}
+
+// store replaces the cached metadata and starts a new generation.
+func (m *schemaMetadata) store(objectTypes []*models.ObjectTypeScheme, objectAttributes []*models.ObjectTypeAttributeScheme, statusTypes *[]StatusTypeMetadata) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.index = newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+	m.generation++
+	m.loadedAt = time.Now()
+}
+
+// snapshot returns the index of the currently cached metadata and its
+// generation, which is zero until the first successful load.
+func (m *schemaMetadata) snapshot() (*metadataIndex, uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.index, m.generation
+}
+
+// indexed returns the index of the object schema metadata, loading it first
+// if needed.
+func (m *schemaMetadata) indexed(ctx context.Context) (*metadataIndex, error) {
+	if err := m.load(ctx); err != nil {
+		return nil, err
+	}
+	index, _ := m.snapshot()
+	return index, nil
+}
+
+// objectType returns the object type with the given name, reloading the
+// metadata once if the type is not known yet.
+func (m *schemaMetadata) objectType(ctx context.Context, name string) (*models.ObjectTypeScheme, error) {
+	if err := m.load(ctx); err != nil {
+		return nil, err
+	}
+	index, generation := m.snapshot()
+	if objectType := getObjectTypeByName(name, index); objectType != nil {
+		return objectType, nil
+	}
+
+	tflog.Debug(ctx, "Object type not found in cached metadata, reloading", map[string]interface{}{
+		"type": name,
+	})
+	if err := m.refresh(ctx, generation); err != nil {
+		return nil, err
+	}
+	index, _ = m.snapshot()
+	if objectType := getObjectTypeByName(name, index); objectType != nil {
+		return objectType, nil
+	}
+	return nil, fmt.Errorf("unknown object type %q in object schema %s", name, m.schemaId)
+}
+
+// objectAttribute returns the attribute with the given name of an object
+// type, reloading the metadata once if the attribute is not known yet.
+func (m *schemaMetadata) objectAttribute(ctx context.Context, objectType string, name string) (*models.ObjectTypeAttributeScheme, error) {
+	if err := m.load(ctx); err != nil {
+		return nil, err
+	}
+	index, generation := m.snapshot()
+	if attr := getObjectAttributeByName(name, objectType, index); attr != nil {
+		return attr, nil
+	}
+	if attr := index.readOnlyAttributesByName[objectAttributeKey{objectType: objectType, name: name}]; attr != nil {
+		return nil, fmt.Errorf("attribute %q of object type %q is read only", name, objectType)
+	}
+
+	tflog.Debug(ctx, "Object attribute not found in cached metadata, reloading", map[string]interface{}{
+		"type":      objectType,
+		"attribute": name,
+	})
+	if err := m.refresh(ctx, generation); err != nil {
+		return nil, err
+	}
+	index, _ = m.snapshot()
+	if attr := getObjectAttributeByName(name, objectType, index); attr != nil {
+		return attr, nil
+	}
+	return nil, fmt.Errorf("unknown attribute %q for object type %q", name, objectType)
+}
+
+type StatusTypeMetadata struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Category       int    `json:"category"`
+	ObjectSchemaId string `json:"objectSchemaId"`
+}
+
+func getConfigStatusType(ctx context.Context, asset *assets.Client, workSpaceID string, schemaId string) (*[]StatusTypeMetadata, error) {
+	apiEndpoint := "/jsm/assets/workspace/" + workSpaceID + "/v1/config/statustype?objectSchemaId=" + schemaId
+
+	request, err := asset.NewRequest(ctx, http.MethodGet, apiEndpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	customResponseStruct := new([]StatusTypeMetadata)
+	response, err := asset.Call(request, &customResponseStruct)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("unable to read status types from %s: %w: %s", response.Endpoint, err, response.Bytes.String())
+		}
+		return nil, fmt.Errorf("unable to read status types: %w", err)
+	}
+	return customResponseStruct, nil
+}
+
+// getObjectSchemaAttributes returns every attribute of an object schema.
+// Attributes that are not value editable, like Key, are included so the
+// attributes of AQL results, which only reference them by ID, resolve.
+func getObjectSchemaAttributes(ctx context.Context, asset *assets.Client, workSpaceID string, schemaID string) ([]*models.ObjectTypeAttributeScheme, error) {
+	options := &models.ObjectSchemaAttributesParamsScheme{
+		OnlyValueEditable: false,
+		Extended:          true,
+		Query:             "",
+	}
+	attributes, response, err := asset.ObjectSchema.Attributes(ctx, workSpaceID, schemaID, options)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("unable to read object schema attributes from %s: %w: %s", response.Endpoint, err, response.Bytes.String())
+		}
+		return nil, fmt.Errorf("unable to read object schema attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+func getObjectSchemaObjectTypes(ctx context.Context, asset *assets.Client, workSpaceID string, objsectSchemaID string) ([]*models.ObjectTypeScheme, error) {
+	schema, response, err := asset.ObjectSchema.ObjectTypes(ctx, workSpaceID, objsectSchemaID, true)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("unable to read object schema object types from %s: %w: %s", response.Endpoint, err, response.Bytes.String())
+		}
+		return nil, fmt.Errorf("unable to read object schema object types: %w", err)
+	}
+	return schema, nil
+}
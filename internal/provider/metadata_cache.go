@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// defaultMetadataCacheTTL is used when metadata_cache_dir is set without
+// metadata_cache_ttl.
+const defaultMetadataCacheTTL = time.Hour
+
+// metadataCacheVersion is stored in every entry, entries of another version
+// are ignored. Version 1 added the attributes that are not value editable.
+const metadataCacheVersion = 1
+
+var unsafeCacheKeyChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// metadataDiskCache stores object schema metadata on disk so separate
+// Terraform runs against the same schema can skip downloading it.
+type metadataDiskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// metadataCacheEntry is the on-disk representation of the metadata of one
+// object schema.
+type metadataCacheEntry struct {
+	Version          int                                 `json:"version"`
+	WorkspaceId      string                              `json:"workspaceId"`
+	ObjectSchemaId   string                              `json:"objectSchemaId"`
+	SchemaUpdated    string                              `json:"schemaUpdated"`
+	FetchedAt        time.Time                           `json:"fetchedAt"`
+	ObjectTypes      []*models.ObjectTypeScheme          `json:"objectTypes"`
+	ObjectAttributes []*models.ObjectTypeAttributeScheme `json:"objectAttributes"`
+	StatusTypes      *[]StatusTypeMetadata               `json:"statusTypes"`
+}
+
+func newMetadataDiskCache(dir string, ttl time.Duration) *metadataDiskCache {
+	if dir == "" {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = defaultMetadataCacheTTL
+	}
+	return &metadataDiskCache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+func (c *metadataDiskCache) path(workspaceId string, schemaId string) string {
+	name := fmt.Sprintf("%s-%s.json",
+		unsafeCacheKeyChars.ReplaceAllString(workspaceId, "_"),
+		unsafeCacheKeyChars.ReplaceAllString(schemaId, "_"),
+	)
+	return filepath.Join(c.dir, name)
+}
+
+// read returns the cached metadata of a schema if it exists, is younger than
+// the TTL and was fetched while the schema had the given updated timestamp.
+func (c *metadataDiskCache) read(workspaceId string, schemaId string, schemaUpdated string) (*metadataCacheEntry, bool) {
+	data, err := os.ReadFile(c.path(workspaceId, schemaId))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(metadataCacheEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+
+	if entry.Version != metadataCacheVersion || entry.WorkspaceId != workspaceId || entry.ObjectSchemaId != schemaId {
+		return nil, false
+	}
+	if entry.SchemaUpdated != schemaUpdated {
+		return nil, false
+	}
+	if time.Since(entry.FetchedAt) > c.ttl {
+		return nil, false
+	}
+	return entry, true
+}
+
+// write stores the metadata of a schema, replacing the file atomically so
+// concurrent runs never read a partial entry.
+func (c *metadataDiskCache) write(entry *metadataCacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	entry.Version = metadataCacheVersion
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".metadata-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.WorkspaceId, entry.ObjectSchemaId))
+}
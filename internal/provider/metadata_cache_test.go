@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMetadataDiskCache(t *testing.T) {
+	cache := newMetadataDiskCache(t.TempDir(), time.Hour)
+	objectTypes, objectAttributes, statusTypes := testMetadata(2, 3, 4)
+
+	err := cache.write(&metadataCacheEntry{
+		WorkspaceId:      "workspace/1",
+		ObjectSchemaId:   "7",
+		SchemaUpdated:    "2024-01-01T00:00:00.000Z",
+		FetchedAt:        time.Now(),
+		ObjectTypes:      objectTypes,
+		ObjectAttributes: objectAttributes,
+		StatusTypes:      statusTypes,
+	})
+	if err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	entry, ok := cache.read("workspace/1", "7", "2024-01-01T00:00:00.000Z")
+	if !ok {
+		t.Fatal("read: expected a cache hit")
+	}
+	if len(entry.ObjectTypes) != 2 || len(entry.ObjectAttributes) != 6 || len(*entry.StatusTypes) != 4 {
+		t.Errorf("read: got %d types, %d attributes, %d statuses", len(entry.ObjectTypes), len(entry.ObjectAttributes), len(*entry.StatusTypes))
+	}
+	index := newMetadataIndex(entry.ObjectTypes, entry.ObjectAttributes, entry.StatusTypes)
+	if attr := getObjectAttributeByName("Attribute 2", "Type 1", index); attr == nil || attr.ID != "6" {
+		t.Errorf("cached attribute lookup = %v, want attribute 6", attr)
+	}
+
+	if _, ok := cache.read("workspace/1", "7", "2024-02-01T00:00:00.000Z"); ok {
+		t.Error("read: expected a miss after the schema was updated")
+	}
+	if _, ok := cache.read("workspace/1", "8", "2024-01-01T00:00:00.000Z"); ok {
+		t.Error("read: expected a miss for another schema")
+	}
+
+	expired := newMetadataDiskCache(cache.dir, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := expired.read("workspace/1", "7", "2024-01-01T00:00:00.000Z"); ok {
+		t.Error("read: expected a miss after the TTL passed")
+	}
+
+	files, err := os.ReadDir(cache.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != filepath.Base(cache.path("workspace/1", "7")) {
+		t.Errorf("cache directory contains %v, want a single entry", files)
+	}
+}
+
+func TestMetadataDiskCacheDisabled(t *testing.T) {
+	if cache := newMetadataDiskCache("", time.Hour); cache != nil {
+		t.Errorf("newMetadataDiskCache with an empty dir = %v, want nil", cache)
+	}
+}
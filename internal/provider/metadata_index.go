@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// objectAttributeKey identifies an attribute by the name of its object type
+// and its own name, attribute names are only unique within an object type.
+type objectAttributeKey struct {
+	objectType string
+	name       string
+}
+
+// metadataIndex holds the object schema metadata keyed for constant time
+// lookups. It is built once per metadata load and never modified afterwards,
+// so it can be shared between goroutines without locking.
+type metadataIndex struct {
+	typesByName      map[string]*models.ObjectTypeScheme
+	typesByID        map[string]*models.ObjectTypeScheme
+	attributesByName map[objectAttributeKey]*models.ObjectTypeAttributeScheme
+	attributesByID   map[string]*models.ObjectTypeAttributeScheme
+	statusesByName   map[string]*StatusTypeMetadata
+	statusesByID     map[string]*StatusTypeMetadata
+	statusNames      []string
+
+	// attributes that are not editable, like Key, are kept apart. They are
+	// only resolved to decode objects and to reject them in configurations.
+	readOnlyAttributesByName map[objectAttributeKey]*models.ObjectTypeAttributeScheme
+	readOnlyAttributesByID   map[string]*models.ObjectTypeAttributeScheme
+}
+
+func newMetadataIndex(objectTypes []*models.ObjectTypeScheme, objectAttributes []*models.ObjectTypeAttributeScheme, statusTypes *[]StatusTypeMetadata) *metadataIndex {
+	index := &metadataIndex{
+		typesByName:      make(map[string]*models.ObjectTypeScheme, len(objectTypes)),
+		typesByID:        make(map[string]*models.ObjectTypeScheme, len(objectTypes)),
+		attributesByName: make(map[objectAttributeKey]*models.ObjectTypeAttributeScheme, len(objectAttributes)),
+		attributesByID:   make(map[string]*models.ObjectTypeAttributeScheme, len(objectAttributes)),
+		statusesByName:   map[string]*StatusTypeMetadata{},
+		statusesByID:     map[string]*StatusTypeMetadata{},
+
+		readOnlyAttributesByName: map[objectAttributeKey]*models.ObjectTypeAttributeScheme{},
+		readOnlyAttributesByID:   map[string]*models.ObjectTypeAttributeScheme{},
+	}
+
+	// the first entry wins on duplicates, matching the order the API returns
+	for _, objectType := range objectTypes {
+		if _, ok := index.typesByName[objectType.Name]; !ok {
+			index.typesByName[objectType.Name] = objectType
+		}
+		if _, ok := index.typesByID[objectType.Id]; !ok {
+			index.typesByID[objectType.Id] = objectType
+		}
+	}
+
+	for _, attr := range objectAttributes {
+		byName, byID := index.attributesByName, index.attributesByID
+		if !attr.Editable {
+			byName, byID = index.readOnlyAttributesByName, index.readOnlyAttributesByID
+		}
+		if _, ok := byID[attr.ID]; !ok {
+			byID[attr.ID] = attr
+		}
+		if attr.ObjectType == nil {
+			continue
+		}
+		key := objectAttributeKey{objectType: attr.ObjectType.Name, name: attr.Name}
+		if _, ok := byName[key]; !ok {
+			byName[key] = attr
+		}
+	}
+
+	if statusTypes != nil {
+		for i := range *statusTypes {
+			status := &(*statusTypes)[i]
+			index.statusNames = append(index.statusNames, status.Name)
+			if _, ok := index.statusesByName[status.Name]; !ok {
+				index.statusesByName[status.Name] = status
+			}
+			if _, ok := index.statusesByID[status.ID]; !ok {
+				index.statusesByID[status.ID] = status
+			}
+		}
+	}
+
+	return index
+}
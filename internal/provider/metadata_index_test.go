@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+func testMetadata(typeCount int, attributeCount int, statusCount int) ([]*models.ObjectTypeScheme, []*models.ObjectTypeAttributeScheme, *[]StatusTypeMetadata) {
+	var objectTypes []*models.ObjectTypeScheme
+	var objectAttributes []*models.ObjectTypeAttributeScheme
+	for t := 0; t < typeCount; t++ {
+		objectType := &models.ObjectTypeScheme{
+			Id:   fmt.Sprint(t + 1),
+			Name: fmt.Sprintf("Type %d", t),
+		}
+		objectTypes = append(objectTypes, objectType)
+		for a := 0; a < attributeCount; a++ {
+			objectAttributes = append(objectAttributes, &models.ObjectTypeAttributeScheme{
+				ID:         fmt.Sprint(t*attributeCount + a + 1),
+				Name:       fmt.Sprintf("Attribute %d", a),
+				ObjectType: objectType,
+				Editable:   true,
+			})
+		}
+	}
+	statusTypes := []StatusTypeMetadata{}
+	for s := 0; s < statusCount; s++ {
+		statusTypes = append(statusTypes, StatusTypeMetadata{
+			ID:   fmt.Sprint(s + 1),
+			Name: fmt.Sprintf("Status %d", s),
+		})
+	}
+	return objectTypes, objectAttributes, &statusTypes
+}
+
+func TestMetadataIndexLookups(t *testing.T) {
+	index := newMetadataIndex(testMetadata(3, 4, 5))
+
+	if got := getObjectTypeByName("Type 2", index); got == nil || got.Id != "3" {
+		t.Errorf("getObjectTypeByName(Type 2) = %v, want type 3", got)
+	}
+	if got := getObjectTypeByName("Missing", index); got != nil {
+		t.Errorf("getObjectTypeByName(Missing) = %v, want nil", got)
+	}
+	if got := index.typesByID["2"]; got == nil || got.Name != "Type 1" {
+		t.Errorf("typesByID[2] = %v, want Type 1", got)
+	}
+
+	attr := getObjectAttributeByName("Attribute 3", "Type 1", index)
+	if attr == nil || attr.ID != "8" {
+		t.Errorf("getObjectAttributeByName(Attribute 3, Type 1) = %v, want attribute 8", attr)
+	}
+	if got := getObjectAttributeByName("Attribute 3", "Missing", index); got != nil {
+		t.Errorf("getObjectAttributeByName(Attribute 3, Missing) = %v, want nil", got)
+	}
+	if got := index.attributesByID["8"]; got != attr {
+		t.Errorf("attributesByID[8] = %v, want %v", got, attr)
+	}
+
+	if id, err := getConfigStatusIDByName("Status 4", index); err != nil || id != "5" {
+		t.Errorf("getConfigStatusIDByName(Status 4) = %q, %v, want 5", id, err)
+	}
+	if _, err := getConfigStatusIDByName("Missing", index); err == nil {
+		t.Error("getConfigStatusIDByName(Missing) returned no error")
+	}
+	if name := getConfigStatusNameByID("1", index); name != "Status 0" {
+		t.Errorf("getConfigStatusNameByID(1) = %q, want Status 0", name)
+	}
+}
+
+func TestMetadataIndexReadOnlyAttributes(t *testing.T) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(1, 1, 0)
+	key := &models.ObjectTypeAttributeScheme{ID: "100", Name: "Key", ObjectType: objectTypes[0], System: true}
+	serial := &models.ObjectTypeAttributeScheme{ID: "101", Name: "Serial", ObjectType: objectTypes[0]}
+	index := newMetadataIndex(objectTypes, append(objectAttributes, key, serial), statusTypes)
+
+	for _, attr := range []*models.ObjectTypeAttributeScheme{key, serial} {
+		if got := getObjectAttributeByName(attr.Name, "Type 0", index); got != nil {
+			t.Errorf("getObjectAttributeByName(%s, Type 0) = %v, want nil for a read only attribute", attr.Name, got)
+		}
+		if got := index.attributesByID[attr.ID]; got != nil {
+			t.Errorf("attributesByID[%s] = %v, want nil for a read only attribute", attr.ID, got)
+		}
+		if got := index.readOnlyAttributesByName[objectAttributeKey{objectType: "Type 0", name: attr.Name}]; got != attr {
+			t.Errorf("readOnlyAttributesByName[%s] = %v, want %v", attr.Name, got, attr)
+		}
+		resolved := resolveObjectAttribute(&models.ObjectAttributeScheme{ObjectTypeAttributeId: attr.ID}, index)
+		if resolved == nil || resolved.ObjectTypeAttribute != attr {
+			t.Errorf("resolveObjectAttribute(%s) = %v, want it resolved to %s", attr.ID, resolved, attr.Name)
+		}
+	}
+}
+
+// linearObjectAttributeByName is the slice scan the index replaced, kept as
+// the baseline for the benchmarks below.
+func linearObjectAttributeByName(objName string, objectType string, schema []*models.ObjectTypeAttributeScheme) *models.ObjectTypeAttributeScheme {
+	for _, obj := range schema {
+		if obj.Name == objName && obj.ObjectType.Name == objectType {
+			return obj
+		}
+	}
+	return nil
+}
+
+func linearConfigStatusNameByID(id string, statusType *[]StatusTypeMetadata) string {
+	for _, statusType := range *statusType {
+		if statusType.ID == id {
+			return statusType.Name
+		}
+	}
+	return ""
+}
+
+func BenchmarkObjectAttributeLookup(b *testing.B) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(200, 50, 20)
+	index := newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			linearObjectAttributeByName("Attribute 49", "Type 199", objectAttributes)
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			getObjectAttributeByName("Attribute 49", "Type 199", index)
+		}
+	})
+}
+
+func BenchmarkStatusLookup(b *testing.B) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(1, 1, 50)
+	index := newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			linearConfigStatusNameByID("50", statusTypes)
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			getConfigStatusNameByID("50", index)
+		}
+	})
+}
+
+func BenchmarkNewMetadataIndex(b *testing.B) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(200, 50, 20)
+	for i := 0; i < b.N; i++ {
+		newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+	}
+}
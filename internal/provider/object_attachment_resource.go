@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &objectAttachmentResource{}
+	_ resource.ResourceWithConfigure      = &objectAttachmentResource{}
+	_ resource.ResourceWithImportState    = &objectAttachmentResource{}
+	_ resource.ResourceWithModifyPlan     = &objectAttachmentResource{}
+	_ resource.ResourceWithValidateConfig = &objectAttachmentResource{}
+)
+
+// NewObjectAttachmentResource is a helper function to simplify the provider implementation.
+func NewObjectAttachmentResource() resource.Resource {
+	return &objectAttachmentResource{}
+}
+
+// objectAttachmentResource is the resource implementation.
+type objectAttachmentResource struct {
+	client      *assets.Client
+	workspaceId string
+}
+
+// Metadata returns the resource type name.
+func (r *objectAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "jiraassets_object_attachment"
+}
+
+type objectAttachmentResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ObjectId      types.String `tfsdk:"object_id"`
+	File          types.String `tfsdk:"file"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Filename      types.String `tfsdk:"filename"`
+	Comment       types.String `tfsdk:"comment"`
+	Checksum      types.String `tfsdk:"checksum"`
+	MimeType      types.String `tfsdk:"mime_type"`
+	Filesize      types.String `tfsdk:"filesize"`
+	Author        types.String `tfsdk:"author"`
+	Created       types.String `tfsdk:"created"`
+	Url           types.String `tfsdk:"url"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Schema defines the schema for the resource.
+func (r *objectAttachmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:    true,
+			Description: description,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "A file attached to a Jira Assets object. The attachment is replaced when its content changes.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": computed("The ID of the attachment."),
+			"object_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object the file is attached to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of the file to upload. Conflicts with content and content_base64.",
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text content to upload. Conflicts with file and content_base64.",
+			},
+			"content_base64": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base64 encoded content to upload. Conflicts with file and content.",
+			},
+			"filename": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name the attachment is uploaded with. Defaults to the base name of file, required with content and content_base64. Imported attachments use the name stored in Assets.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "A comment stored with the attachment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 checksum of the uploaded content. A different checksum replaces the attachment.",
+			},
+			"mime_type": computed("The MIME type of the attachment."),
+			"filesize":  computed("The size of the attachment as reported by Assets."),
+			"author":    computed("The user who uploaded the attachment."),
+			"created":   computed("When the attachment was uploaded."),
+			"url":       computed("The URL to download the attachment."),
+		},
+	}
+}
+
+// ValidateConfig checks that exactly one content source is configured.
+func (r *objectAttachmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config objectAttachmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sources []string
+	if !config.File.IsNull() {
+		sources = append(sources, "file")
+	}
+	if !config.Content.IsNull() {
+		sources = append(sources, "content")
+	}
+	if !config.ContentBase64.IsNull() {
+		sources = append(sources, "content_base64")
+	}
+	switch {
+	case len(sources) == 0:
+		resp.Diagnostics.AddError(
+			"Missing attachment content",
+			"One of file, content or content_base64 must be set.",
+		)
+	case len(sources) > 1:
+		resp.Diagnostics.AddAttributeError(
+			path.Root(sources[1]),
+			"Conflicting attachment content",
+			"Only one of file, content and content_base64 can be set, got "+strings.Join(sources, " and ")+".",
+		)
+	case sources[0] != "file" && config.Filename.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filename"),
+			"Missing attachment filename",
+			"filename must be set when the attachment is uploaded from "+sources[0]+".",
+		)
+	}
+}
+
+// ModifyPlan plans the checksum of the configured content and replaces the
+// attachment when it differs from the uploaded one.
+func (r *objectAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan objectAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.File.IsUnknown() || plan.Content.IsUnknown() || plan.ContentBase64.IsUnknown() {
+		return
+	}
+
+	content, filename, err := attachmentContent(plan.File, plan.Content, plan.ContentBase64, plan.Filename)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid attachment content",
+			err.Error(),
+		)
+		return
+	}
+	checksum := contentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), types.StringValue(checksum))...)
+	if plan.Filename.IsUnknown() && req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("filename"), types.StringValue(filename))...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var priorChecksum types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("checksum"), &priorChecksum)...)
+	// imported attachments have no checksum and are assumed to match
+	if !priorChecksum.IsNull() && priorChecksum.ValueString() != checksum {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("checksum"))
+	}
+}
+
+// Create uploads the attachment and sets the initial Terraform state.
+func (r *objectAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, filename, err := attachmentContent(plan.File, plan.Content, plan.ContentBase64, plan.Filename)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid attachment content",
+			err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Uploading attachment.", map[string]interface{}{
+		"object_id": plan.ObjectId.ValueString(),
+		"filename":  filename,
+		"size":      len(content),
+	})
+	attachment, response, err := uploadObjectAttachment(ctx, r.client, r.workspaceId, plan.ObjectId.ValueString(), filename, content, plan.Comment.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during attachment upload", err, response, nil)...)
+		return
+	}
+
+	plan.Checksum = types.StringValue(contentHash(content))
+	plan.Filename = types.StringValue(filename)
+	plan.setAttachment(attachment)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// setAttachment copies the computed values of an attachment. The uploaded
+// file name is kept, Assets may store the file under another name and
+// filename would then replace the attachment on every plan. Only imported
+// attachments take the name from Assets.
+func (m *objectAttachmentResourceModel) setAttachment(attachment *objectAttachment) {
+	m.Id = types.StringValue(attachment.ID.String())
+	if m.Filename.IsNull() || m.Filename.IsUnknown() {
+		m.Filename = types.StringValue(attachment.Filename)
+	}
+	m.MimeType = types.StringValue(attachment.MimeType)
+	m.Filesize = types.StringValue(attachment.Filesize)
+	m.Author = types.StringValue(attachment.Author)
+	m.Created = types.StringValue(attachment.Created)
+	m.Url = types.StringValue(attachment.URL)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *objectAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attachments, response, err := listObjectAttachments(ctx, r.client, r.workspaceId, state.ObjectId.ValueString())
+	if errors.Is(err, errObjectNotFound) {
+		tflog.Warn(ctx, "Object of attachment not found, removing it from state.", map[string]interface{}{
+			"object_id": state.ObjectId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during attachment reading", err, response, nil)...)
+		return
+	}
+
+	for _, attachment := range attachments {
+		if attachment.ID.String() == state.Id.ValueString() {
+			state.setAttachment(attachment)
+			diags = resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	tflog.Warn(ctx, "Attachment not found, removing it from state.", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+	resp.State.RemoveResource(ctx)
+}
+
+// Update only stores the new configuration, attachments cannot be changed
+// in place and every other change replaces them.
+func (r *objectAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan objectAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *objectAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if response, err := deleteObjectAttachment(ctx, r.client, r.workspaceId, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during attachment deletion", err, response, nil)...)
+		return
+	}
+}
+
+// ImportState imports an attachment by "<object id>/<attachment id>".
+func (r *objectAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	objectId, id, ok := strings.Cut(req.ID, "/")
+	if !ok || objectId == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected <object id>/<attachment id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_id"), objectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure configures the resource with the given configuration.
+func (r *objectAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspaceId = providerClient.workspaceId
+}
@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testAttachmentModel(objectId string) objectAttachmentResourceModel {
+	return objectAttachmentResourceModel{
+		Id:            types.StringUnknown(),
+		ObjectId:      types.StringValue(objectId),
+		File:          types.StringNull(),
+		Content:       types.StringNull(),
+		ContentBase64: types.StringNull(),
+		Filename:      types.StringUnknown(),
+		Comment:       types.StringNull(),
+		Checksum:      types.StringUnknown(),
+		MimeType:      types.StringUnknown(),
+		Filesize:      types.StringUnknown(),
+		Author:        types.StringUnknown(),
+		Created:       types.StringUnknown(),
+		Url:           types.StringUnknown(),
+		Timeouts:      nullTimeouts(),
+	}
+}
+
+// testAttachmentRequiresReplace plans config on top of prior and returns the
+// paths that require a replacement.
+func testAttachmentRequiresReplace(t *testing.T, r *objectAttachmentResource, prior objectAttachmentResourceModel, config objectAttachmentResourceModel) (path.Paths, diag.Diagnostics) {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	configRaw := testResourceValue(t, r, &config)
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: configRaw}}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: configRaw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: configRaw},
+		State:  tfsdk.State{Schema: s, Raw: testResourceValue(t, r, &prior)},
+	}, resp)
+	return resp.RequiresReplace, resp.Diagnostics
+}
+
+// testHostObject creates a host to attach files and comments to.
+func testHostObject(t *testing.T, client JiraAssetsProviderClient) string {
+	t.Helper()
+
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+	object, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Name": "web01"}))
+	if diags.HasError() {
+		t.Fatalf("Create object: %v", diags)
+	}
+	return object.Id.ValueString()
+}
+
+func TestObjectAttachmentResourceLifecycle(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+	r := testResource(t, NewObjectAttachmentResource().(*objectAttachmentResource), client)
+
+	file := filepath.Join(t.TempDir(), "runbook.txt")
+	if err := os.WriteFile(file, []byte("first version"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := testAttachmentModel(objectId)
+	config.File = types.StringValue(file)
+	config.Comment = types.StringValue("Runbook")
+	planned, diags := testResourceModifyPlan(t, r, nil, config)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if planned.Filename.ValueString() != "runbook.txt" || planned.Checksum.ValueString() != contentHash([]byte("first version")) {
+		t.Errorf("planned filename %s and checksum %s", planned.Filename, planned.Checksum)
+	}
+
+	created, diags := testResourceCreate(t, r, planned)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	id := created.Id.ValueString()
+	if string(fake.contents[id]) != "first version" {
+		t.Errorf("uploaded content = %q", fake.contents[id])
+	}
+	if attachments := fake.attachments[objectId]; len(attachments) != 1 || attachments[0].Comment != "Runbook" || attachments[0].Filename != "runbook.txt" {
+		t.Errorf("stored attachments = %+v", attachments)
+	}
+
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if read.Url.ValueString() == "" || read.Checksum != created.Checksum {
+		t.Errorf("Read returned url %s and checksum %s", read.Url, read.Checksum)
+	}
+
+	// unchanged content keeps the attachment
+	planned, diags = testResourceModifyPlan(t, r, &read, read)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if planned.Checksum != read.Checksum {
+		t.Errorf("unchanged content planned checksum %s", planned.Checksum)
+	}
+
+	// changed content replaces it
+	if err := os.WriteFile(file, []byte("second version"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if replace, diags := testAttachmentRequiresReplace(t, r, read, read); diags.HasError() || len(replace) != 1 {
+		t.Errorf("changed content requires replace of %v: %v", replace, diags)
+	}
+
+	if diags := testResourceDelete(t, r, read); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if len(fake.attachments[objectId]) != 0 {
+		t.Error("attachment still exists after Delete")
+	}
+	if _, removed, diags := testResourceRead(t, r, read); diags.HasError() || !removed {
+		t.Errorf("Read of a deleted attachment: removed=%t %v", removed, diags)
+	}
+	if diags := testResourceDelete(t, r, read); diags.HasError() {
+		t.Errorf("Delete of a deleted attachment: %v", diags)
+	}
+}
+
+func TestObjectAttachmentResourceImport(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+	r := testResource(t, NewObjectAttachmentResource().(*objectAttachmentResource), client)
+
+	config := testAttachmentModel(objectId)
+	config.Content = types.StringValue("hello")
+	config.Filename = types.StringValue("hello.txt")
+	planned, diags := testResourceModifyPlan(t, r, nil, config)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	created, diags := testResourceCreate(t, r, planned)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	if _, diags := testResourceImport[objectAttachmentResourceModel](t, r, created.Id.ValueString()); !diags.HasError() {
+		t.Error("import without object id returned no error")
+	}
+	imported, diags := testResourceImport[objectAttachmentResourceModel](t, r, objectId+"/"+created.Id.ValueString())
+	if diags.HasError() {
+		t.Fatalf("ImportState: %v", diags)
+	}
+	read, removed, diags := testResourceRead(t, r, imported)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if read.Filename.ValueString() != "hello.txt" || !read.Checksum.IsNull() {
+		t.Errorf("imported filename %s and checksum %s", read.Filename, read.Checksum)
+	}
+
+	// imported attachments are assumed to match the configuration
+	config.Id = read.Id
+	config.Checksum = read.Checksum
+	if replace, diags := testAttachmentRequiresReplace(t, r, read, config); diags.HasError() || len(replace) != 0 {
+		t.Errorf("ModifyPlan after import: replace %v %v", replace, diags)
+	}
+}
+
+func TestObjectAttachmentResourceRenamed(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.attachmentPrefix = "1_"
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+	r := testResource(t, NewObjectAttachmentResource().(*objectAttachmentResource), client)
+
+	config := testAttachmentModel(objectId)
+	config.Content = types.StringValue("hello")
+	config.Filename = types.StringValue("hello.txt")
+	planned, diags := testResourceModifyPlan(t, r, nil, config)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	created, diags := testResourceCreate(t, r, planned)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	if attachments := fake.attachments[objectId]; len(attachments) != 1 || attachments[0].Filename != "1_hello.txt" {
+		t.Fatalf("stored attachments = %+v", attachments)
+	}
+
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if read.Filename.ValueString() != "hello.txt" {
+		t.Errorf("Read set filename %s, want the configured hello.txt", read.Filename)
+	}
+
+	// planning the configuration again changes nothing
+	plan := read
+	plan.Filename = config.Filename
+	plan.Content = config.Content
+	replanned, diags := testResourceModifyPlan(t, r, &read, plan)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if !testResourceValue(t, r, &replanned).Equal(testResourceValue(t, r, &read)) {
+		t.Errorf("plan after Read = %+v, want the read state %+v", replanned, read)
+	}
+	if replace, diags := testAttachmentRequiresReplace(t, r, read, plan); diags.HasError() || len(replace) != 0 {
+		t.Errorf("plan after Read requires replace of %v: %v", replace, diags)
+	}
+
+	// imported attachments take the stored name
+	imported, diags := testResourceImport[objectAttachmentResourceModel](t, r, objectId+"/"+created.Id.ValueString())
+	if diags.HasError() {
+		t.Fatalf("ImportState: %v", diags)
+	}
+	if read, _, diags := testResourceRead(t, r, imported); diags.HasError() || read.Filename.ValueString() != "1_hello.txt" {
+		t.Errorf("imported filename %s: %v", read.Filename, diags)
+	}
+}
+
+func TestObjectAttachmentResourceValidation(t *testing.T) {
+	r := NewObjectAttachmentResource().(*objectAttachmentResource)
+
+	config := testAttachmentModel("1")
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("missing content returned no error")
+	}
+
+	config.Content = types.StringValue("hello")
+	config.Filename = types.StringNull()
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("content without filename returned no error")
+	}
+
+	config.Filename = types.StringValue("hello.txt")
+	config.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte("hello")))
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("content with content_base64 returned no error")
+	}
+
+	config.Content = types.StringNull()
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+}
+
+func TestObjectAttachmentsDataSource(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+	r := testResource(t, NewObjectAttachmentResource().(*objectAttachmentResource), client)
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		config := testAttachmentModel(objectId)
+		config.Content = types.StringValue(name)
+		config.Filename = types.StringValue(name)
+		planned, diags := testResourceModifyPlan(t, r, nil, config)
+		if diags.HasError() {
+			t.Fatalf("ModifyPlan: %v", diags)
+		}
+		if _, diags := testResourceCreate(t, r, planned); diags.HasError() {
+			t.Fatalf("Create %s: %v", name, diags)
+		}
+	}
+
+	ctx := context.Background()
+	d := NewObjectAttachmentsDataSource().(*objectAttachmentsDataSource)
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"object_id":   tftypes.NewValue(tftypes.String, objectId),
+			"attachments": tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["attachments"], nil),
+		}),
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var state objectAttachmentsDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state: %v", diags)
+	}
+	if len(state.Attachments) != 2 || state.Attachments[0].Filename.ValueString() != "a.txt" || state.Attachments[1].Filename.ValueString() != "b.txt" {
+		t.Errorf("attachments = %+v", state.Attachments)
+	}
+}
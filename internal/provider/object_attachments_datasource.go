@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &objectAttachmentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &objectAttachmentsDataSource{}
+)
+
+func NewObjectAttachmentsDataSource() datasource.DataSource {
+	return &objectAttachmentsDataSource{}
+}
+
+// objectAttachmentsDataSource lists the attachments of an object.
+type objectAttachmentsDataSource struct {
+	client       *assets.Client
+	workspace_id string
+}
+
+// Metadata returns the data source type name.
+func (d *objectAttachmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_attachments"
+}
+
+// objectAttachmentsDataSourceModel describes the data source model.
+type objectAttachmentsDataSourceModel struct {
+	ObjectId    types.String                  `tfsdk:"object_id"`
+	Attachments []objectAttachmentsEntryModel `tfsdk:"attachments"`
+}
+
+type objectAttachmentsEntryModel struct {
+	Id       types.String `tfsdk:"id"`
+	Filename types.String `tfsdk:"filename"`
+	MimeType types.String `tfsdk:"mime_type"`
+	Filesize types.String `tfsdk:"filesize"`
+	Author   types.String `tfsdk:"author"`
+	Created  types.String `tfsdk:"created"`
+	Comment  types.String `tfsdk:"comment"`
+	Url      types.String `tfsdk:"url"`
+}
+
+// Schema defines the schema for the data source.
+func (d *objectAttachmentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the attachments of a Jira Assets object.",
+		Attributes: map[string]schema.Attribute{
+			"object_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object.",
+			},
+			"attachments": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The attachments of the object.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"filename": schema.StringAttribute{
+							Computed: true,
+						},
+						"mime_type": schema.StringAttribute{
+							Computed: true,
+						},
+						"filesize": schema.StringAttribute{
+							Computed: true,
+						},
+						"author": schema.StringAttribute{
+							Computed: true,
+						},
+						"created": schema.StringAttribute{
+							Computed: true,
+						},
+						"comment": schema.StringAttribute{
+							Computed: true,
+						},
+						"url": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *objectAttachmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading object attachments data source")
+
+	var state objectAttachmentsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attachments, response, err := listObjectAttachments(ctx, d.client, d.workspace_id, state.ObjectId.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Unable to read Assets object attachments", err, response, nil)...)
+		return
+	}
+
+	state.Attachments = make([]objectAttachmentsEntryModel, 0, len(attachments))
+	for _, attachment := range attachments {
+		state.Attachments = append(state.Attachments, objectAttachmentsEntryModel{
+			Id:       types.StringValue(attachment.ID.String()),
+			Filename: types.StringValue(attachment.Filename),
+			MimeType: types.StringValue(attachment.MimeType),
+			Filesize: types.StringValue(attachment.Filesize),
+			Author:   types.StringValue(attachment.Author),
+			Created:  types.StringValue(attachment.Created),
+			Comment:  types.StringValue(attachment.Comment),
+			Url:      types.StringValue(attachment.URL),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *objectAttachmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assets.Client, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerClient.client
+	d.workspace_id = providerClient.workspaceId
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &objectCommentResource{}
+	_ resource.ResourceWithConfigure   = &objectCommentResource{}
+	_ resource.ResourceWithImportState = &objectCommentResource{}
+)
+
+// NewObjectCommentResource is a helper function to simplify the provider implementation.
+func NewObjectCommentResource() resource.Resource {
+	return &objectCommentResource{}
+}
+
+// objectCommentResource is the resource implementation.
+type objectCommentResource struct {
+	client      *assets.Client
+	workspaceId string
+}
+
+// Metadata returns the resource type name.
+func (r *objectCommentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "jiraassets_object_comment"
+}
+
+type objectCommentResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	ObjectId types.String `tfsdk:"object_id"`
+	Body     types.String `tfsdk:"body"`
+	Role     types.Int64  `tfsdk:"role"`
+	Created  types.String `tfsdk:"created"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Schema defines the schema for the resource.
+func (r *objectCommentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A comment on a Jira Assets object. Assets does not delete comments, destroying the resource only removes it from the state.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the comment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object to comment on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				Required:    true,
+				Description: "The text of the comment. Changing it posts a new comment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Description: "The role that can see the comment, 0 for every user of the object schema. Changing it posts a new comment.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the comment was posted.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create posts the comment and sets the initial Terraform state.
+func (r *objectCommentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectCommentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Posting comment.", map[string]interface{}{
+		"object_id": plan.ObjectId.ValueString(),
+	})
+	comment, response, err := createObjectComment(ctx, r.client, r.workspaceId, plan.ObjectId.ValueString(), plan.Body.ValueString(), plan.Role.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during comment creation", err, response, nil)...)
+		return
+	}
+
+	plan.Id = types.StringValue(comment.ID.String())
+	plan.Created = types.StringValue(comment.Created)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *objectCommentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectCommentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comments, response, err := listObjectComments(ctx, r.client, r.workspaceId, state.ObjectId.ValueString())
+	if errors.Is(err, errObjectNotFound) {
+		tflog.Warn(ctx, "Object of comment not found, removing it from state.", map[string]interface{}{
+			"object_id": state.ObjectId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during comment reading", err, response, nil)...)
+		return
+	}
+
+	for _, comment := range comments {
+		if comment.ID.String() == state.Id.ValueString() {
+			state.Body = types.StringValue(comment.Comment)
+			state.Role = types.Int64Value(comment.Role)
+			state.Created = types.StringValue(comment.Created)
+			diags = resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	tflog.Warn(ctx, "Comment not found, removing it from state.", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+	resp.State.RemoveResource(ctx)
+}
+
+// Update stores changed timeouts, the only change not requiring a new
+// comment. Every other change posts a new one.
+func (r *objectCommentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan objectCommentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the comment from the Terraform state. The Assets API has no
+// endpoint to delete comments, so it stays on the object.
+func (r *objectCommentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectCommentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Comment not deleted",
+		fmt.Sprintf("Assets comments cannot be deleted through the API. Comment %s was removed from the state but remains on object %s.", state.Id.ValueString(), state.ObjectId.ValueString()),
+	)
+}
+
+// ImportState imports a comment by "<object id>/<comment id>".
+func (r *objectCommentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	objectId, id, ok := strings.Cut(req.ID, "/")
+	if !ok || objectId == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected <object id>/<comment id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_id"), objectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure configures the resource with the given configuration.
+func (r *objectCommentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspaceId = providerClient.workspaceId
+}
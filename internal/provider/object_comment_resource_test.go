@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testCommentModel(objectId string, body string) objectCommentResourceModel {
+	return objectCommentResourceModel{
+		Id:       types.StringUnknown(),
+		ObjectId: types.StringValue(objectId),
+		Body:     types.StringValue(body),
+		Role:     types.Int64Value(0),
+		Created:  types.StringUnknown(),
+		Timeouts: nullTimeouts(),
+	}
+}
+
+func TestObjectCommentResourceLifecycle(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+	r := testResource(t, NewObjectCommentResource().(*objectCommentResource), client)
+
+	plan := testCommentModel(objectId, "Patched to 2.4")
+	plan.Role = types.Int64Value(1)
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	if comments := fake.comments[objectId]; len(comments) != 1 || comments[0].Comment != "Patched to 2.4" || comments[0].Role != 1 {
+		t.Errorf("stored comments = %+v", comments)
+	}
+
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if read.Id != created.Id || read.Body.ValueString() != "Patched to 2.4" || read.Created.ValueString() == "" {
+		t.Errorf("Read = %+v", read)
+	}
+
+	// changing only the timeouts updates the state in place
+	updated, diags := testResourceUpdate(t, r, read, read)
+	if diags.HasError() || updated.Id != read.Id || updated.Created != read.Created {
+		t.Errorf("Update: %+v %v", updated, diags)
+	}
+
+	imported, diags := testResourceImport[objectCommentResourceModel](t, r, objectId+"/"+created.Id.ValueString())
+	if diags.HasError() {
+		t.Fatalf("ImportState: %v", diags)
+	}
+	read, removed, diags = testResourceRead(t, r, imported)
+	if diags.HasError() || removed || read.Body.ValueString() != "Patched to 2.4" || read.Role.ValueInt64() != 1 {
+		t.Errorf("Read after import: removed=%t %+v %v", removed, read, diags)
+	}
+	if _, diags := testResourceImport[objectCommentResourceModel](t, r, created.Id.ValueString()); !diags.HasError() {
+		t.Error("import without object id returned no error")
+	}
+
+	// comments cannot be deleted, Delete warns and leaves them
+	diags = testResourceDelete(t, r, read)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("Delete: %v", diags)
+	}
+	if len(fake.comments[objectId]) != 1 {
+		t.Error("Delete removed the comment")
+	}
+
+	delete(fake.objects, objectId)
+	if _, removed, diags := testResourceRead(t, r, read); diags.HasError() || !removed {
+		t.Errorf("Read of a comment on a deleted object: removed=%t %v", removed, diags)
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &objectConnectedTicketsDataSource{}
+	_ datasource.DataSourceWithConfigure = &objectConnectedTicketsDataSource{}
+)
+
+func NewObjectConnectedTicketsDataSource() datasource.DataSource {
+	return &objectConnectedTicketsDataSource{}
+}
+
+// objectConnectedTicketsDataSource lists the Jira issues connected to an
+// object.
+type objectConnectedTicketsDataSource struct {
+	client          *assets.Client
+	workspace_id    string
+	closed_statuses []string
+}
+
+// Metadata returns the data source type name.
+func (d *objectConnectedTicketsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_connected_tickets"
+}
+
+// objectConnectedTicketsDataSourceModel describes the data source model.
+type objectConnectedTicketsDataSourceModel struct {
+	ObjectId        types.String                `tfsdk:"object_id"`
+	OpenCount       types.Int64                 `tfsdk:"open_count"`
+	AllTicketsQuery types.String                `tfsdk:"all_tickets_query"`
+	Tickets         []connectedTicketEntryModel `tfsdk:"tickets"`
+}
+
+type connectedTicketEntryModel struct {
+	Id       types.String `tfsdk:"id"`
+	Key      types.String `tfsdk:"key"`
+	Summary  types.String `tfsdk:"summary"`
+	Status   types.String `tfsdk:"status"`
+	Type     types.String `tfsdk:"type"`
+	Priority types.String `tfsdk:"priority"`
+	Created  types.String `tfsdk:"created"`
+	Updated  types.String `tfsdk:"updated"`
+	Open     types.Bool   `tfsdk:"open"`
+}
+
+// Schema defines the schema for the data source.
+func (d *objectConnectedTicketsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Jira issues connected to a Jira Assets object.",
+		Attributes: map[string]schema.Attribute{
+			"object_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object.",
+			},
+			"open_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of connected issues that are not done.",
+			},
+			"all_tickets_query": schema.StringAttribute{
+				Computed:    true,
+				Description: "A JQL query that returns all connected issues.",
+			},
+			"tickets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The connected issues.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"summary": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"priority": schema.StringAttribute{
+							Computed: true,
+						},
+						"created": schema.StringAttribute{
+							Computed: true,
+						},
+						"updated": schema.StringAttribute{
+							Computed: true,
+						},
+						"open": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the issue is not done, see closed_ticket_statuses of the provider.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *objectConnectedTicketsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading object connected tickets data source")
+
+	var state objectConnectedTicketsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	page, response, err := connectedTickets(ctx, d.client, d.workspace_id, state.ObjectId.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Unable to read Assets object connected tickets", err, response, nil)...)
+		return
+	}
+
+	var open int64
+	state.AllTicketsQuery = types.StringValue(page.AllTicketsQuery)
+	state.Tickets = make([]connectedTicketEntryModel, 0, len(page.Tickets))
+	for _, ticket := range page.Tickets {
+		entry := connectedTicketEntryModel{
+			Id:       types.StringValue(ticket.Id),
+			Key:      types.StringValue(ticket.Key),
+			Summary:  types.StringValue(ticket.Title),
+			Status:   types.StringValue(""),
+			Type:     types.StringValue(""),
+			Priority: types.StringValue(""),
+			Created:  types.StringValue(ticket.Created),
+			Updated:  types.StringValue(ticket.Updated),
+			Open:     types.BoolValue(ticketIsOpen(ticket, d.closed_statuses)),
+		}
+		if ticket.Status != nil {
+			entry.Status = types.StringValue(ticket.Status.Name)
+		}
+		if ticket.Type != nil {
+			entry.Type = types.StringValue(ticket.Type.Name)
+		}
+		if ticket.Priority != nil {
+			entry.Priority = types.StringValue(ticket.Priority.Name)
+		}
+		if entry.Open.ValueBool() {
+			open++
+		}
+		state.Tickets = append(state.Tickets, entry)
+	}
+	state.OpenCount = types.Int64Value(open)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *objectConnectedTicketsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assets.Client, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerClient.client
+	d.workspace_id = providerClient.workspaceId
+	d.closed_statuses = providerClient.closedTicketStatuses
+}
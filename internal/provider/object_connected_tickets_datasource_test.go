@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestObjectConnectedTicketsDataSource(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+
+	fake.addTicket(objectId, "CHG-1", "Done", "green")
+	fake.addTicket(objectId, "INC-7", "In Progress", "yellow")
+
+	state, diags := testDataSourceRead(t, NewObjectConnectedTicketsDataSource().(*objectConnectedTicketsDataSource), client, objectConnectedTicketsDataSourceModel{
+		ObjectId: types.StringValue(objectId),
+	})
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	if state.OpenCount.ValueInt64() != 1 || state.AllTicketsQuery.ValueString() == "" {
+		t.Errorf("open_count %s, all_tickets_query %s", state.OpenCount, state.AllTicketsQuery)
+	}
+	if len(state.Tickets) != 2 {
+		t.Fatalf("tickets = %+v", state.Tickets)
+	}
+	done, open := state.Tickets[0], state.Tickets[1]
+	if done.Key.ValueString() != "CHG-1" || done.Open.ValueBool() || done.Status.ValueString() != "Done" {
+		t.Errorf("done ticket = %+v", done)
+	}
+	if open.Key.ValueString() != "INC-7" || !open.Open.ValueBool() || open.Type.ValueString() != "Change" || open.Summary.ValueString() != "Ticket INC-7" {
+		t.Errorf("open ticket = %+v", open)
+	}
+}
+
+func TestObjectConnectedTicketsClosedStatuses(t *testing.T) {
+	fake := newFakeAssets(t)
+	config := testProviderConfig(fake)
+	config.ClosedTicketStatuses = []string{"Resolved", "Won't Do"}
+	client, diags := testConfigureProvider(t, config)
+	if diags.HasError() {
+		t.Fatalf("Configure: %v", diags)
+	}
+	objectId := testHostObject(t, client)
+
+	// the configured statuses decide, whatever their color
+	fake.addTicket(objectId, "CHG-1", "resolved", "yellow")
+	fake.addTicket(objectId, "CHG-2", "Won't Do", "blue-gray")
+	fake.addTicket(objectId, "INC-7", "Awaiting Deployment", "green")
+
+	state, diags := testDataSourceRead(t, NewObjectConnectedTicketsDataSource().(*objectConnectedTicketsDataSource), client, objectConnectedTicketsDataSourceModel{
+		ObjectId: types.StringValue(objectId),
+	})
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	if state.OpenCount.ValueInt64() != 1 || len(state.Tickets) != 3 {
+		t.Fatalf("open_count %s, tickets %+v", state.OpenCount, state.Tickets)
+	}
+	for _, ticket := range state.Tickets {
+		if open := ticket.Key.ValueString() == "INC-7"; ticket.Open.ValueBool() != open {
+			t.Errorf("ticket %s in status %s: open = %s, want %t", ticket.Key, ticket.Status, ticket.Open, open)
+		}
+	}
+
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web02"})
+	plan.PreventDestroyIfOpenTickets = types.BoolValue(true)
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	fake.addTicket(created.Id.ValueString(), "INC-8", "Done", "green")
+	if diags := testResourceDelete(t, r, created); !diags.HasError() {
+		t.Error("Delete with a ticket outside closed_ticket_statuses returned no error")
+	}
+}
+
+func TestObjectResourcePreventDestroyIfOpenTickets(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	plan.PreventDestroyIfOpenTickets = types.BoolValue(true)
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	fake.addTicket(created.Id.ValueString(), "CHG-1", "Done", "green")
+	fake.addTicket(created.Id.ValueString(), "INC-7", "In Progress", "yellow")
+
+	if diags := testResourceDelete(t, r, created); !diags.HasError() {
+		t.Fatal("Delete with an open ticket returned no error")
+	}
+	if _, ok := fake.objects[created.Id.ValueString()]; !ok {
+		t.Fatal("object was deleted although a ticket is open")
+	}
+
+	fake.tickets[created.Id.ValueString()][1].Status.ColorName = "green"
+	if diags := testResourceDelete(t, r, created); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if _, ok := fake.objects[created.Id.ValueString()]; ok {
+		t.Error("object still exists after Delete")
+	}
+}
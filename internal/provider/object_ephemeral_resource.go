@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &objectEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &objectEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &objectEphemeralResource{}
+)
+
+// NewObjectEphemeralResource is a helper function to simplify the provider implementation.
+func NewObjectEphemeralResource() ephemeral.EphemeralResource {
+	return &objectEphemeralResource{}
+}
+
+// objectEphemeralResource reads attributes of an object without storing them
+// in the plan or state, e.g. credentials needed by other providers.
+type objectEphemeralResource struct {
+	client         *assets.Client
+	workspaceId    string
+	objectschemaId string
+	metadata       *schemaMetadataRegistry
+}
+
+type objectEphemeralResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ObjectKey  types.String `tfsdk:"object_key"`
+	Aql        types.String `tfsdk:"aql"`
+	Attributes types.List   `tfsdk:"attributes"`
+	Type       types.String `tfsdk:"type"`
+	Label      types.String `tfsdk:"label"`
+	Values     types.Map    `tfsdk:"values"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *objectEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "jiraassets_object"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *objectEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads attributes of a Jira Assets object without storing them in the plan or state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the object. Exactly one of id, object_key and aql must be set.",
+			},
+			"object_key": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The key of the object, e.g. ITSM-1234.",
+			},
+			"aql": schema.StringAttribute{
+				Optional:    true,
+				Description: "An AQL query that matches exactly one object.",
+			},
+			"attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Names of the attributes to read.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the object type.",
+			},
+			"label": schema.StringAttribute{
+				Computed:    true,
+				Description: "The label of the object.",
+			},
+			"values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The values of the requested attributes by name, decoded like the attributes of jiraassets_object. Attributes without a value are empty strings.",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the object is selected in exactly one way.
+func (r *objectEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config objectEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := 0
+	for _, value := range []types.String{config.Id, config.ObjectKey, config.Aql} {
+		if !value.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid object selection",
+			fmt.Sprintf("Exactly one of id, object_key and aql must be set, got %d.", set),
+		)
+	}
+}
+
+// Open fetches the object and the values of the requested attributes.
+func (r *objectEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data objectEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(data.Attributes.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var object *models.ObjectScheme
+	var err error
+	switch {
+	case !data.ObjectKey.IsNull():
+		object, err = findSingleObject(ctx, r.client, r.workspaceId, "Key = "+aqlQuote(data.ObjectKey.ValueString()))
+	case !data.Aql.IsNull():
+		object, err = findSingleObject(ctx, r.client, r.workspaceId, data.Aql.ValueString())
+	default:
+		var response *models.ResponseScheme
+		object, response, err = r.client.Object.Get(ctx, r.workspaceId, data.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during object reading", err, response, nil)...)
+			return
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object reading",
+			err.Error(),
+		)
+		return
+	}
+
+	attrs, response, err := r.client.Object.Attributes(ctx, r.workspaceId, object.ID)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during object attributes reading", err, response, nil)...)
+		return
+	}
+
+	// the object's own schema decides how its attribute values are decoded
+	objectSchemaId := r.objectschemaId
+	objectType := ""
+	if object.ObjectType != nil {
+		objectType = object.ObjectType.Name
+		if object.ObjectType.ObjectSchemaId != "" {
+			objectSchemaId = object.ObjectType.ObjectSchemaId
+		}
+	}
+	index, err := r.metadata.forSchema(objectSchemaId).indexed(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to load object schema metadata",
+			err.Error(),
+		)
+		return
+	}
+
+	decoded := objectAttributeValues(attrs, index, nil)
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if getObjectAttributeByName(name, objectType, index) == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("attributes").AtListIndex(i),
+				"Unknown object attribute",
+				fmt.Sprintf("Object type %q has no attribute %q.", objectType, name),
+			)
+			continue
+		}
+		values[name] = decoded[name]
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Opened object", map[string]interface{}{
+		"id":         object.ID,
+		"attributes": names,
+	})
+
+	data.Id = types.StringValue(object.ID)
+	data.ObjectKey = types.StringValue(object.ObjectKey)
+	data.Type = types.StringValue(objectType)
+	data.Label = types.StringValue(object.Label)
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	data.Values = mapValue
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Configure configures the ephemeral resource with the given configuration.
+func (r *objectEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspaceId = providerClient.workspaceId
+	r.objectschemaId = providerClient.objectschemaId
+	r.metadata = providerClient.metadata
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testEphemeralOpen runs Open with the model as configuration and returns
+// the result.
+func testEphemeralOpen(t *testing.T, r *objectEphemeralResource, config objectEphemeralResourceModel) (objectEphemeralResourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	raw := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("building ephemeral resource config: %v", diags)
+	}
+
+	validateResp := &ephemeral.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw}}, validateResp)
+	if validateResp.Diagnostics.HasError() {
+		return config, validateResp.Diagnostics
+	}
+
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: raw.Raw}}
+	r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw}}, resp)
+
+	var result objectEphemeralResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Result.Get(ctx, &result)...)
+	}
+	return result, resp.Diagnostics
+}
+
+func testEphemeralModel(names ...string) objectEphemeralResourceModel {
+	elements := make([]attr.Value, 0, len(names))
+	for _, name := range names {
+		elements = append(elements, types.StringValue(name))
+	}
+	return objectEphemeralResourceModel{
+		Id:         types.StringNull(),
+		ObjectKey:  types.StringNull(),
+		Aql:        types.StringNull(),
+		Attributes: types.ListValueMust(types.StringType, elements),
+		Type:       types.StringUnknown(),
+		Label:      types.StringUnknown(),
+		Values:     types.MapUnknown(types.StringType),
+	}
+}
+
+func TestObjectEphemeralResourceOpen(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.addAttribute(fake.objectType("1"), "14", "Password", 0, false)
+	client := testProviderClient(t, fake)
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Status": "Active"})
+	plan.SensitiveAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Password": "hunter2"}))
+	web, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	e := NewObjectEphemeralResource().(*objectEphemeralResource)
+	configureResp := &ephemeral.ConfigureResponse{}
+	e.Configure(context.Background(), ephemeral.ConfigureRequest{ProviderData: client}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure: %v", configureResp.Diagnostics)
+	}
+
+	byId := testEphemeralModel("Password", "Status", "Hostname")
+	byId.Id = web.Id
+	byKey := testEphemeralModel("Password", "Status", "Hostname")
+	byKey.ObjectKey = web.ObjectKey
+	byAql := testEphemeralModel("Password", "Status", "Hostname")
+	byAql.Aql = types.StringValue(`objectType = Host AND Name = "web01"`)
+	for _, config := range []objectEphemeralResourceModel{byId, byKey, byAql} {
+		result, diags := testEphemeralOpen(t, e, config)
+		if diags.HasError() {
+			t.Fatalf("Open: %v", diags)
+		}
+		if result.Id != web.Id || result.ObjectKey != web.ObjectKey || result.Type.ValueString() != "Host" || result.Label.ValueString() != "web01" {
+			t.Errorf("Open = %+v", result)
+		}
+		testAssertAttributes(t, testMapStrings(t, result.Values), map[string]string{
+			"Password": "hunter2",
+			"Status":   "Active",
+			"Hostname": "",
+		})
+	}
+
+	unknown := testEphemeralModel("Pasword")
+	unknown.Id = web.Id
+	if _, diags := testEphemeralOpen(t, e, unknown); !diags.HasError() {
+		t.Error("unknown attribute returned no error")
+	}
+
+	both := testEphemeralModel("Password")
+	both.Id = web.Id
+	both.ObjectKey = web.ObjectKey
+	if _, diags := testEphemeralOpen(t, e, both); !diags.HasError() {
+		t.Error("id and object_key returned no error")
+	}
+}
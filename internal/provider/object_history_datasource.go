@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &objectHistoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &objectHistoryDataSource{}
+)
+
+func NewObjectHistoryDataSource() datasource.DataSource {
+	return &objectHistoryDataSource{}
+}
+
+// objectHistoryDataSource returns the change history of an object.
+type objectHistoryDataSource struct {
+	client       *assets.Client
+	workspace_id string
+}
+
+// Metadata returns the data source type name.
+func (d *objectHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_history"
+}
+
+// objectHistoryDataSourceModel describes the data source model.
+type objectHistoryDataSourceModel struct {
+	ObjectId  types.String              `tfsdk:"object_id"`
+	Since     types.String              `tfsdk:"since"`
+	Until     types.String              `tfsdk:"until"`
+	Attribute types.String              `tfsdk:"attribute"`
+	Entries   []objectHistoryEntryModel `tfsdk:"entries"`
+}
+
+type objectHistoryEntryModel struct {
+	Id                types.String `tfsdk:"id"`
+	Actor             types.String `tfsdk:"actor"`
+	ActorKey          types.String `tfsdk:"actor_key"`
+	Created           types.String `tfsdk:"created"`
+	AffectedAttribute types.String `tfsdk:"affected_attribute"`
+	OldValue          types.String `tfsdk:"old_value"`
+	NewValue          types.String `tfsdk:"new_value"`
+	Type              types.Int64  `tfsdk:"type"`
+}
+
+// Schema defines the schema for the data source.
+func (d *objectHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The change history of a Jira Assets object, oldest entry first.",
+		Attributes: map[string]schema.Attribute{
+			"object_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the object.",
+			},
+			"since": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return entries created at or after this RFC 3339 timestamp.",
+			},
+			"until": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return entries created before this RFC 3339 timestamp.",
+			},
+			"attribute": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return entries of the attribute with this name.",
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The history entries of the object.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"actor": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the user who made the change.",
+						},
+						"actor_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key of the user who made the change.",
+						},
+						"created": schema.StringAttribute{
+							Computed: true,
+						},
+						"affected_attribute": schema.StringAttribute{
+							Computed: true,
+						},
+						"old_value": schema.StringAttribute{
+							Computed: true,
+						},
+						"new_value": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The kind of change as reported by Assets, e.g. object created or attribute changed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseAssetsTime parses the timestamps used by Assets, which are RFC 3339
+// or carry a numeric zone without colon.
+func parseAssetsTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05.000-0700", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, err
+}
+
+// parseTimeFilter parses an optional since or until value.
+func parseTimeFilter(value types.String) (*time.Time, error) {
+	if value.IsNull() || value.ValueString() == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *objectHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading object history data source")
+
+	var state objectHistoryDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since, err := parseTimeFilter(state.Since)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("since"), "Invalid timestamp", err.Error())
+	}
+	until, err := parseTimeFilter(state.Until)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("until"), "Invalid timestamp", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	history, response, err := d.client.Object.History(ctx, d.workspace_id, state.ObjectId.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Unable to read Assets object history", err, response, nil)...)
+		return
+	}
+
+	state.Entries = make([]objectHistoryEntryModel, 0, len(history))
+	for _, entry := range history {
+		if !state.Attribute.IsNull() && !strings.EqualFold(entry.AffectedAttribute, state.Attribute.ValueString()) {
+			continue
+		}
+		if since != nil || until != nil {
+			created, err := parseAssetsTime(entry.Created)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to filter Assets object history",
+					fmt.Sprintf("history entry %s has an invalid timestamp: %s", entry.ID, err),
+				)
+				return
+			}
+			if (since != nil && created.Before(*since)) || (until != nil && !created.Before(*until)) {
+				continue
+			}
+		}
+
+		model := objectHistoryEntryModel{
+			Id:                types.StringValue(entry.ID),
+			Actor:             types.StringValue(""),
+			ActorKey:          types.StringValue(""),
+			Created:           types.StringValue(entry.Created),
+			AffectedAttribute: types.StringValue(entry.AffectedAttribute),
+			OldValue:          types.StringValue(entry.OldValue),
+			NewValue:          types.StringValue(entry.NewValue),
+			Type:              types.Int64Value(int64(entry.Type)),
+		}
+		if entry.Actor != nil {
+			model.Actor = types.StringValue(entry.Actor.DisplayName)
+			model.ActorKey = types.StringValue(entry.Actor.Key)
+		}
+		state.Entries = append(state.Entries, model)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *objectHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assets.Client, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerClient.client
+	d.workspace_id = providerClient.workspaceId
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testObjectHistory(t *testing.T, client JiraAssetsProviderClient, config objectHistoryDataSourceModel) []objectHistoryEntryModel {
+	t.Helper()
+
+	state, diags := testDataSourceRead(t, NewObjectHistoryDataSource().(*objectHistoryDataSource), client, config)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	return state.Entries
+}
+
+func TestObjectHistoryDataSource(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	objectId := testHostObject(t, client)
+
+	fake.addHistory(objectId, "Alice", "2024-03-01T10:00:00.000Z", "Hostname", "", "web01.example.com")
+	fake.addHistory(objectId, "Bob", "2024-03-02T10:00:00.000+0000", "Status", "Active", "Retired")
+	fake.addHistory(objectId, "Alice", "2024-03-03T10:00:00.000Z", "Hostname", "web01.example.com", "web01.internal")
+
+	config := objectHistoryDataSourceModel{
+		ObjectId:  types.StringValue(objectId),
+		Since:     types.StringNull(),
+		Until:     types.StringNull(),
+		Attribute: types.StringNull(),
+	}
+	entries := testObjectHistory(t, client, config)
+	if len(entries) != 3 || entries[0].Actor.ValueString() != "Alice" || entries[1].ActorKey.ValueString() != "bob" {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries[2].OldValue.ValueString() != "web01.example.com" || entries[2].NewValue.ValueString() != "web01.internal" || entries[2].Type.ValueInt64() != 2 {
+		t.Errorf("newest entry = %+v", entries[2])
+	}
+
+	config.Attribute = types.StringValue("hostname")
+	if entries := testObjectHistory(t, client, config); len(entries) != 2 {
+		t.Errorf("filtered by attribute to %d entries, want 2", len(entries))
+	}
+
+	config.Attribute = types.StringNull()
+	config.Since = types.StringValue("2024-03-02T00:00:00Z")
+	config.Until = types.StringValue("2024-03-03T10:00:00Z")
+	if entries := testObjectHistory(t, client, config); len(entries) != 1 || entries[0].AffectedAttribute.ValueString() != "Status" {
+		t.Errorf("filtered by date to %+v", entries)
+	}
+
+	config.Since = types.StringValue("yesterday")
+	if _, diags := testDataSourceRead(t, NewObjectHistoryDataSource().(*objectHistoryDataSource), client, config); !diags.HasError() {
+		t.Error("invalid since returned no error")
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseObjectKeyFunction{}
+
+// NewParseObjectKeyFunction is a helper function to simplify the provider implementation.
+func NewParseObjectKeyFunction() function.Function {
+	return &parseObjectKeyFunction{}
+}
+
+// parseObjectKeyFunction splits an object key into the key prefix of its
+// object schema and its number.
+type parseObjectKeyFunction struct{}
+
+type objectKeyModel struct {
+	Prefix types.String `tfsdk:"prefix"`
+	Number types.Int64  `tfsdk:"number"`
+}
+
+// parseObjectKey splits a key like ITSM-1234 at its last dash.
+func parseObjectKey(key string) (objectKeyModel, error) {
+	i := strings.LastIndex(key, "-")
+	if i <= 0 {
+		return objectKeyModel{}, fmt.Errorf("invalid object key %q, expected <prefix>-<number>", key)
+	}
+	prefix, number := key[:i], key[i+1:]
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || strings.TrimLeft(number, "0123456789") != "" {
+		return objectKeyModel{}, fmt.Errorf("invalid object key %q, %q is not an object number", key, number)
+	}
+	return objectKeyModel{
+		Prefix: types.StringValue(prefix),
+		Number: types.Int64Value(n),
+	}, nil
+}
+
+// Metadata returns the function name.
+func (f *parseObjectKeyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_object_key"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseObjectKeyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split an object key",
+		Description: "Splits an object key like `ITSM-1234` into the key prefix of its object schema and the object number. The result has the attributes `prefix` and `number`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "key",
+				Description: "The object key.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"prefix": types.StringType,
+				"number": types.Int64Type,
+			},
+		},
+	}
+}
+
+// Run parses the key.
+func (f *parseObjectKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+	resp.Error = req.Arguments.Get(ctx, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseObjectKey(key)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parsed)
+}
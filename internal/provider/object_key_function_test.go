@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseObjectKeyFunction(t *testing.T) {
+	attrTypes := map[string]attr.Type{"prefix": types.StringType, "number": types.Int64Type}
+	tests := map[string]objectKeyModel{
+		"ITSM-1234":   {Prefix: types.StringValue("ITSM"), Number: types.Int64Value(1234)},
+		"MY-CMDB-007": {Prefix: types.StringValue("MY-CMDB"), Number: types.Int64Value(7)},
+	}
+	for key, want := range tests {
+		got, err := testFunctionRun(t, NewParseObjectKeyFunction(), types.ObjectUnknown(attrTypes), types.StringValue(key))
+		if err != nil {
+			t.Errorf("parse_object_key(%q): %v", key, err)
+			continue
+		}
+		wantValue := types.ObjectValueMust(attrTypes, map[string]attr.Value{"prefix": want.Prefix, "number": want.Number})
+		if !got.Equal(wantValue) {
+			t.Errorf("parse_object_key(%q) = %s, want %s", key, got, wantValue)
+		}
+	}
+
+	for _, key := range []string{"", "ITSM", "-12", "ITSM-", "ITSM-12a", "ITSM-+1"} {
+		if _, err := testFunctionRun(t, NewParseObjectKeyFunction(), types.ObjectUnknown(attrTypes), types.StringValue(key)); err == nil {
+			t.Errorf("parse_object_key(%q) returned no error", key)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &objectListResource{}
+	_ list.ListResourceWithConfigure = &objectListResource{}
+)
+
+// objectListPageSize is the number of objects requested per AQL page.
+const objectListPageSize = 100
+
+// NewObjectListResource is a helper function to simplify the provider implementation.
+func NewObjectListResource() list.ListResource {
+	return &objectListResource{}
+}
+
+// objectListResource lists jiraassets_object resources matching an AQL query,
+// so `terraform query` can generate import blocks and configuration for them.
+type objectListResource struct {
+	client         *assets.Client
+	workspaceId    string
+	objectschemaId string
+	ignoreKeys     []string
+	metadata       *schemaMetadataRegistry
+}
+
+type objectListResourceModel struct {
+	Aql types.String `tfsdk:"aql"`
+}
+
+// Metadata returns the resource type name.
+func (r *objectListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "jiraassets_object"
+}
+
+// ListResourceConfigSchema defines the schema of the list block.
+func (r *objectListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists Jira Assets objects matching an AQL query.",
+		Attributes: map[string]schema.Attribute{
+			"aql": schema.StringAttribute{
+				Required:    true,
+				Description: "The AQL query selecting the objects, e.g. `objectType = Host`.",
+			},
+		},
+	}
+}
+
+// List streams the objects matching the query, page by page.
+func (r *objectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config objectListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	aql := config.Aql.ValueString()
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for startAt := 0; ; {
+			page, response, err := r.client.Object.Filter(ctx, r.workspaceId, aql, req.IncludeResource, startAt, objectListPageSize)
+			if err != nil {
+				detail := err.Error()
+				if response != nil {
+					detail = fmt.Sprintf("%s: %s", detail, response.Bytes.String())
+				}
+				var diags diag.Diagnostics
+				diags.AddError("Error during object listing", "Unable to search objects with AQL "+aql+": "+detail)
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			for _, object := range page.Values {
+				if req.Limit > 0 && count >= req.Limit {
+					return
+				}
+				count++
+				if !push(r.listResult(ctx, req, object)) {
+					return
+				}
+			}
+
+			if page.IsLast || len(page.Values) == 0 {
+				return
+			}
+			startAt += len(page.Values)
+		}
+	}
+}
+
+// listResult converts an AQL result into the identity and, when requested,
+// the full state of a jiraassets_object resource.
+func (r *objectListResource) listResult(ctx context.Context, req list.ListRequest, object *models.ObjectScheme) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = object.Label
+	if object.ObjectKey != "" {
+		result.DisplayName = object.ObjectKey + " " + object.Label
+	}
+	result.Diagnostics.Append(result.Identity.Set(ctx, objectResourceIdentityModel{Id: types.StringValue(object.ID)})...)
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	objectSchemaId := r.objectschemaId
+	objectType := ""
+	if object.ObjectType != nil {
+		objectType = object.ObjectType.Name
+		if object.ObjectType.ObjectSchemaId != "" {
+			objectSchemaId = object.ObjectType.ObjectSchemaId
+		}
+	}
+	index, err := r.metadata.forSchema(objectSchemaId).indexed(ctx)
+	if err != nil {
+		result.Diagnostics.AddError(
+			"Unable to load object schema metadata",
+			err.Error(),
+		)
+		return result
+	}
+
+	// values that cannot be decoded are left out, a configuration generated
+	// with empty strings would clear them on the next apply
+	values := objectAttributeValues(object.Attributes, index, r.ignoreKeys)
+	if undecodable := undecodableAttributeNames(object.Attributes, index); len(undecodable) > 0 {
+		for _, name := range undecodable {
+			delete(values, name)
+		}
+		result.Diagnostics.AddWarning(
+			"Unsupported object attributes",
+			fmt.Sprintf("The values of attributes %s of object %s have types the provider cannot manage and are left out of attributes.", strings.Join(undecodable, ", "), object.ObjectKey),
+		)
+	}
+	attributes, diags := types.MapValueFrom(ctx, types.StringType, values)
+	result.Diagnostics.Append(diags...)
+	allAttributes, diags := types.MapValueFrom(ctx, types.StringType, allObjectAttributeValues(object.Attributes, index))
+	result.Diagnostics.Append(diags...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	tflog.Debug(ctx, "Listed object", map[string]interface{}{
+		"id":   object.ID,
+		"type": objectType,
+	})
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, objectResourceModel{
+		WorkspaceId:    types.StringValue(object.WorkspaceId),
+		GlobalId:       types.StringValue(object.GlobalId),
+		Id:             types.StringValue(object.ID),
+		Label:          types.StringValue(object.Label),
+		ObjectKey:      types.StringValue(object.ObjectKey),
+		Created:        types.StringValue(object.Created),
+		Updated:        types.StringValue(object.Updated),
+		HasAvatar:      types.BoolValue(object.HasAvatar),
+		ObjectSchemaId: types.StringValue(objectSchemaId),
+		Type:           types.StringValue(objectType),
+		Attributes:     attributes,
+		AllAttributes:  allAttributes,
+		AvatarUuid:     types.StringNull(),
+		AvatarFile:     types.StringNull(),
+		AvatarBase64:   types.StringNull(),
+		AvatarHash:     types.StringNull(),
+		AdoptBy:        types.ListNull(types.StringType),
+
+		IgnoreAttributes:               types.ListNull(types.StringType),
+		ManageOnlyConfiguredAttributes: types.BoolValue(false),
+		ChangeComment:                  types.StringNull(),
+		PreventDestroyIfOpenTickets:    types.BoolValue(false),
+		DeletionPolicy:                 types.StringValue(deletionPolicyDelete),
+		DeletionStatus:                 types.StringNull(),
+		DeletionAttributes:             types.MapNull(types.StringType),
+		SensitiveAttributes:            types.MapNull(types.StringType),
+		SensitiveAttributesWo:          types.MapNull(types.StringType),
+		SensitiveAttributesWoVersion:   types.Int64Null(),
+		WriteOnlyAttributeNames:        types.ListNull(types.StringType),
+		Timeouts:                       nullTimeouts(),
+	})...)
+	return result
+}
+
+// Configure configures the list resource with the given configuration.
+func (r *objectListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspaceId = providerClient.workspaceId
+	r.objectschemaId = providerClient.objectschemaId
+	r.ignoreKeys = providerClient.ignoreKeys
+	r.metadata = providerClient.metadata
+}
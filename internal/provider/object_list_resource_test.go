@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+type testListResult struct {
+	displayName string
+	identity    objectResourceIdentityModel
+	resource    objectResourceModel
+	diagnostics diag.Diagnostics
+}
+
+func testObjectList(t *testing.T, client JiraAssetsProviderClient, aql string, includeResource bool, limit int64) []testListResult {
+	t.Helper()
+
+	ctx := context.Background()
+	r := NewObjectListResource().(*objectListResource)
+	configureResp := &resource.ConfigureResponse{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, schemaResp)
+	config := tfsdk.Config{Schema: schemaResp.Schema}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &objectListResourceModel{Aql: types.StringValue(aql)}); diags.HasError() {
+		t.Fatalf("building list config: %v", diags)
+	}
+	config.Raw = state.Raw
+
+	objects := NewObjectResource().(*objectResource)
+	identityResp := &resource.IdentitySchemaResponse{}
+	objects.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	stream := &list.ListResultsStream{}
+	r.List(ctx, list.ListRequest{
+		Config:                 config,
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         testResourceSchema(t, objects).Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}, stream)
+
+	var results []testListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("List %s: %v", aql, result.Diagnostics)
+		}
+		listed := testListResult{displayName: result.DisplayName, diagnostics: result.Diagnostics}
+		if diags := result.Identity.Get(ctx, &listed.identity); diags.HasError() {
+			t.Fatalf("identity: %v", diags)
+		}
+		if includeResource {
+			if diags := result.Resource.Get(ctx, &listed.resource); diags.HasError() {
+				t.Fatalf("resource: %v", diags)
+			}
+		}
+		results = append(results, listed)
+	}
+	return results
+}
+
+func TestObjectListResource(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+
+	var hosts []objectResourceModel
+	for _, name := range []string{"web01", "web02", "web03"} {
+		host, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+			"Name":     name,
+			"Hostname": name + ".example.com",
+			"Status":   "Active",
+		}))
+		if diags.HasError() {
+			t.Fatalf("Create %s: %v", name, diags)
+		}
+		hosts = append(hosts, host)
+	}
+	if _, diags := testResourceCreate(t, r, testObjectModel(t, "Application", map[string]string{"Name": "billing"})); diags.HasError() {
+		t.Fatalf("Create application: %v", diags)
+	}
+
+	results := testObjectList(t, client, "objectType = Host", true, 0)
+	if len(results) != len(hosts) {
+		t.Fatalf("listed %d objects, want %d", len(results), len(hosts))
+	}
+	for i, result := range results {
+		if result.identity.Id.ValueString() != hosts[i].Id.ValueString() {
+			t.Errorf("result %d has identity %q, want %q", i, result.identity.Id.ValueString(), hosts[i].Id.ValueString())
+		}
+		if result.resource.Type.ValueString() != "Host" || result.resource.ObjectSchemaId.ValueString() != "1" {
+			t.Errorf("result %d is %q in schema %q, want Host in 1", i, result.resource.Type.ValueString(), result.resource.ObjectSchemaId.ValueString())
+		}
+		// AQL results only reference attribute types by ID, names and
+		// statuses come from the schema metadata
+		testAssertAttributes(t, testObjectAttributes(t, result.resource), testObjectAttributes(t, hosts[i]))
+	}
+
+	if results := testObjectList(t, client, "objectType = Host", false, 2); len(results) != 2 {
+		t.Errorf("listed %d objects with limit 2", len(results))
+	} else if !results[0].resource.Id.IsNull() {
+		t.Error("resource data returned although it was not requested")
+	}
+}
+
+func TestObjectListResourceUnsupportedAttributes(t *testing.T) {
+	fake := newFakeAssets(t)
+	owner := fake.addAttribute(fake.objectType("1"), "14", "Owner", 2, false)
+	client := testProviderClient(t, fake)
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+
+	host, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":   "web01",
+		"Status": "Active",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	setFakeAttribute(fake.objects[host.Id.ValueString()], owner, []*models.ObjectTypeAssetAttributeValueScheme{{Value: "5b10ac8d82e05b22cc7d4ef5", DisplayValue: "Jane Doe"}})
+
+	results := testObjectList(t, client, "objectType = Host", true, 0)
+	if len(results) != 1 {
+		t.Fatalf("listed %d objects, want 1", len(results))
+	}
+	// a user cannot be written back, so it must not end up as "" in the
+	// generated configuration
+	testAssertAttributes(t, testObjectAttributes(t, results[0].resource), map[string]string{
+		"Name":   "web01",
+		"Status": "Active",
+	})
+	if warnings := results[0].diagnostics.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "Owner") {
+		t.Errorf("warnings = %v, want one naming Owner", warnings)
+	}
+}
+
+func TestObjectListResourcePaging(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	r := testResource(t, NewObjectResource().(*objectResource), client)
+
+	want := objectListPageSize + 5
+	for i := 0; i < want; i++ {
+		if _, diags := testResourceCreate(t, r, testObjectModel(t, "Application", map[string]string{"Name": "app"})); diags.HasError() {
+			t.Fatalf("Create: %v", diags)
+		}
+	}
+
+	results := testObjectList(t, client, "objectType = Application", false, 0)
+	if len(results) != want {
+		t.Errorf("listed %d objects, want %d", len(results), want)
+	}
+	if count := fake.requestCount("POST", "/object/aql"); count != 2 {
+		t.Errorf("AQL was called %d times, want 2 pages", count)
+	}
+}
+
+func TestProviderSchemaListResources(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("GetProviderSchema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if _, ok := resp.ListResourceSchemas["jiraassets_object"]; !ok {
+		t.Error("jiraassets_object list resource is not registered")
+	}
+
+	identity, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := identity.IdentitySchemas["jiraassets_object"]; !ok {
+		t.Error("jiraassets_object has no identity schema")
+	}
+}
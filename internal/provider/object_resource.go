@@ -3,27 +3,36 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
 	"github.com/ctreminiom/go-atlassian/assets"
 	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &objectResource{}
-	_ resource.ResourceWithConfigure   = &objectResource{}
-	_ resource.ResourceWithImportState = &objectResource{}
+	_ resource.Resource                   = &objectResource{}
+	_ resource.ResourceWithConfigure      = &objectResource{}
+	_ resource.ResourceWithImportState    = &objectResource{}
+	_ resource.ResourceWithModifyPlan     = &objectResource{}
+	_ resource.ResourceWithIdentity       = &objectResource{}
+	_ resource.ResourceWithValidateConfig = &objectResource{}
 )
 
 // NewObjectResource is a helper function to simplify the provider implementation.
@@ -33,13 +42,12 @@ func NewObjectResource() resource.Resource {
 
 // objectResource is the resource implementation.
 type objectResource struct {
-	client                 *assets.Client
-	workspaceId            string
-	objectschemaId         string
-	ignoreKeys             []string
-	objectSchemaTypes      []*models.ObjectTypeScheme
-	objectSchemaAttributes []*models.ObjectTypeAttributeScheme
-	configStatusType       *[]StatusTypeMetadata
+	client               *assets.Client
+	workspaceId          string
+	objectschemaId       string
+	ignoreKeys           []string
+	closedTicketStatuses []string
+	metadata             *schemaMetadataRegistry
 }
 
 // Metadata returns the resource type name.
@@ -66,9 +74,43 @@ type objectResourceModel struct {
 	Updated     types.String `tfsdk:"updated"`
 	HasAvatar   types.Bool   `tfsdk:"has_avatar"`
 
-	Type       types.String `tfsdk:"type"`
-	Attributes types.Map    `tfsdk:"attributes"`
-	AvatarUuid types.String `tfsdk:"avatar_uuid"`
+	ObjectSchemaId types.String `tfsdk:"object_schema_id"`
+	Type           types.String `tfsdk:"type"`
+	Attributes     types.Map    `tfsdk:"attributes"`
+	AllAttributes  types.Map    `tfsdk:"all_attributes"`
+	AvatarUuid     types.String `tfsdk:"avatar_uuid"`
+	AvatarFile     types.String `tfsdk:"avatar_file"`
+	AvatarBase64   types.String `tfsdk:"avatar_base64"`
+	AvatarHash     types.String `tfsdk:"avatar_hash"`
+	AdoptBy        types.List   `tfsdk:"adopt_by"`
+
+	IgnoreAttributes               types.List   `tfsdk:"ignore_attributes"`
+	ManageOnlyConfiguredAttributes types.Bool   `tfsdk:"manage_only_configured_attributes"`
+	ChangeComment                  types.String `tfsdk:"change_comment"`
+	PreventDestroyIfOpenTickets    types.Bool   `tfsdk:"prevent_destroy_if_open_tickets"`
+	DeletionPolicy                 types.String `tfsdk:"deletion_policy"`
+	DeletionStatus                 types.String `tfsdk:"deletion_status"`
+	DeletionAttributes             types.Map    `tfsdk:"deletion_attributes"`
+
+	SensitiveAttributes          types.Map   `tfsdk:"sensitive_attributes"`
+	SensitiveAttributesWo        types.Map   `tfsdk:"sensitive_attributes_wo"`
+	SensitiveAttributesWoVersion types.Int64 `tfsdk:"sensitive_attributes_wo_version"`
+	WriteOnlyAttributeNames      types.List  `tfsdk:"write_only_attribute_names"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Values of deletion_policy.
+const (
+	deletionPolicyDelete    = "delete"
+	deletionPolicyAbandon   = "abandon"
+	deletionPolicySetStatus = "set_status"
+)
+
+// objectResourceIdentityModel is the identity of an object, used by import
+// blocks and list results.
+type objectResourceIdentityModel struct {
+	Id types.String `tfsdk:"id"`
 }
 
 // type objectAttrResourceModel struct {
@@ -76,39 +118,121 @@ type objectResourceModel struct {
 // 	AttrValue types.String `tfsdk:"attr_value"`
 // }
 
-func getObjectTypeByName(objName string, schema []*models.ObjectTypeScheme) *models.ObjectTypeScheme {
-	for _, obj := range schema {
-		if obj.Name == objName {
-			return obj
-		}
-	}
-	return &models.ObjectTypeScheme{}
+func getObjectTypeByName(objName string, index *metadataIndex) *models.ObjectTypeScheme {
+	return index.typesByName[objName]
 }
 
-func getObjectAttributeByName(objName string, objectType string, schema []*models.ObjectTypeAttributeScheme) *models.ObjectTypeAttributeScheme {
-	for _, obj := range schema {
-		if obj.Name == objName && obj.ObjectType.Name == objectType {
-			return obj
-		}
-	}
-	return &models.ObjectTypeAttributeScheme{}
+func getObjectAttributeByName(objName string, objectType string, index *metadataIndex) *models.ObjectTypeAttributeScheme {
+	return index.attributesByName[objectAttributeKey{objectType: objectType, name: objName}]
 }
 
-func getAttributeValue(attr *models.ObjectAttributeScheme, statusType *[]StatusTypeMetadata) (string, error) {
+func getAttributeValue(attr *models.ObjectAttributeScheme, statusType *metadataIndex) (string, error) {
+	if len(attr.ObjectAttributeValues) == 0 {
+		return "", nil
+	}
 	switch attr.ObjectTypeAttribute.Type {
 	case 1:
 		return attr.ObjectAttributeValues[0].SearchValue, nil
 	case 0:
 		return attr.ObjectAttributeValues[0].Value, nil
 	case 7:
+		if attr.ObjectAttributeValues[0].Status == nil {
+			return "", fmt.Errorf("status attribute without status")
+		}
 		return getConfigStatusNameByID(attr.ObjectAttributeValues[0].Status.ID, statusType), nil
 	default:
 		return "", fmt.Errorf("unsupported attribute type: %d", attr.ObjectTypeAttribute.Type)
 	}
 }
 
-func returnAttributePayloadValue(name string, value string, objectType string, objectSchemaAttributes []*models.ObjectTypeAttributeScheme, statusType *[]StatusTypeMetadata) (*models.ObjectPayloadAttributeScheme, error) {
-	attrSchema := getObjectAttributeByName(name, objectType, objectSchemaAttributes)
+// decodeAttributeValue returns the value of any attribute type. References
+// are returned as object keys and statuses by name, multiple values are
+// joined with a comma.
+func decodeAttributeValue(attr *models.ObjectAttributeScheme, index *metadataIndex) string {
+	values := make([]string, 0, len(attr.ObjectAttributeValues))
+	for _, value := range attr.ObjectAttributeValues {
+		switch {
+		case attr.ObjectTypeAttribute.Type == 1:
+			values = append(values, value.SearchValue)
+		case attr.ObjectTypeAttribute.Type == 7 && value.Status != nil:
+			values = append(values, getConfigStatusNameByID(value.Status.ID, index))
+		case value.Value != "":
+			values = append(values, value.Value)
+		default:
+			values = append(values, value.DisplayValue)
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+// resolveObjectAttribute returns attr with its attribute type set. Attributes
+// returned by AQL only reference their attribute type by ID, which is
+// resolved through the index, including read only attributes like Key. It
+// returns nil for unknown attribute types.
+func resolveObjectAttribute(attr *models.ObjectAttributeScheme, index *metadataIndex) *models.ObjectAttributeScheme {
+	if attr.ObjectTypeAttribute != nil {
+		return attr
+	}
+	attrSchema := index.attributesByID[attr.ObjectTypeAttributeId]
+	if attrSchema == nil {
+		attrSchema = index.readOnlyAttributesByID[attr.ObjectTypeAttributeId]
+	}
+	if attrSchema == nil {
+		return nil
+	}
+	resolved := *attr
+	resolved.ObjectTypeAttribute = attrSchema
+	return &resolved
+}
+
+// allObjectAttributeValues decodes every attribute of an object, including
+// the ones that are not managed by the resource.
+func allObjectAttributeValues(attrs []*models.ObjectAttributeScheme, index *metadataIndex) map[string]string {
+	attributes := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		if attr = resolveObjectAttribute(attr, index); attr != nil {
+			attributes[attr.ObjectTypeAttribute.Name] = decodeAttributeValue(attr, index)
+		}
+	}
+	return attributes
+}
+
+// objectAttributeValues decodes the attributes of an object into a map of
+// attribute names to values.
+func objectAttributeValues(attrs []*models.ObjectAttributeScheme, index *metadataIndex, ignoreKeys []string) map[string]string {
+	// only map known attributes in the state, this is because the API return computed attributes like "key", "created",
+	// and "updated". CI Class in my instance also messes up the state
+	ignore_keys := append([]string{"Created", "Key", "Updated"}, ignoreKeys...)
+
+	attributes := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		if attr = resolveObjectAttribute(attr, index); attr == nil {
+			continue
+		}
+		if !(slices.Contains(ignore_keys, attr.ObjectTypeAttribute.Name)) {
+			attributes[attr.ObjectTypeAttribute.Name], _ = getAttributeValue(attr, index)
+		}
+	}
+	return attributes
+}
+
+// undecodableAttributeNames returns the sorted names of the attributes with
+// values getAttributeValue cannot decode, e.g. users or groups.
+func undecodableAttributeNames(attrs []*models.ObjectAttributeScheme, index *metadataIndex) []string {
+	var names []string
+	for _, attr := range attrs {
+		if attr = resolveObjectAttribute(attr, index); attr == nil {
+			continue
+		}
+		if _, err := getAttributeValue(attr, index); err != nil {
+			names = append(names, attr.ObjectTypeAttribute.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func returnAttributePayloadValue(attrSchema *models.ObjectTypeAttributeScheme, value string, statusType *metadataIndex) (*models.ObjectPayloadAttributeScheme, error) {
 	var err error
 	val := value
 	if attrSchema.Type == 7 {
@@ -127,30 +251,287 @@ func returnAttributePayloadValue(name string, value string, objectType string, o
 	}, nil
 }
 
-func getConfigStatusIDByName(status string, statusType *[]StatusTypeMetadata) (string, error) {
-	statuses := []string{}
-	for _, statusType := range *statusType {
-		statuses = append(statuses, statusType.Name)
-		if statusType.Name == status {
-			return statusType.ID, nil
+func getConfigStatusIDByName(status string, statusType *metadataIndex) (string, error) {
+	if s, ok := statusType.statusesByName[status]; ok {
+		return s.ID, nil
+	}
+	return "", fmt.Errorf("unknown status, available statuses: %s", strings.Join(statusType.statusNames, ","))
+}
+
+func getConfigStatusNameByID(id string, statusType *metadataIndex) string {
+	if s, ok := statusType.statusesByID[id]; ok {
+		return s.Name
+	}
+	return ""
+}
+
+// schemaMetadata returns the metadata cache of the object schema configured
+// on the resource, falling back to the provider's object schema.
+func (r *objectResource) schemaMetadata(objectSchemaId types.String) *schemaMetadata {
+	if objectSchemaId.IsNull() || objectSchemaId.IsUnknown() || objectSchemaId.ValueString() == "" {
+		return r.metadata.forSchema(r.objectschemaId)
+	}
+	return r.metadata.forSchema(objectSchemaId.ValueString())
+}
+
+// attributePayload resolves the configured attribute names of an object type
+// and converts their values into the API payload.
+func attributePayload(ctx context.Context, metadata *schemaMetadata, objectType string, elements map[string]types.String) ([]*models.ObjectPayloadAttributeScheme, error) {
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for attr_type, attr_value := range elements {
+		attrSchema, err := metadata.objectAttribute(ctx, objectType, attr_type)
+		if err != nil {
+			return nil, err
+		}
+		v, err := returnAttributePayloadValue(attrSchema, attr_value.ValueString(), index)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, v)
+	}
+	return attributes, nil
+}
+
+// mergeElements returns the attribute values of all maps in one map.
+func mergeElements(elements ...map[string]types.String) map[string]types.String {
+	merged := make(map[string]types.String)
+	for _, e := range elements {
+		maps.Copy(merged, e)
+	}
+	return merged
+}
+
+// sensitiveElements returns the values of sensitive_attributes and, when
+// writeOnly is set, of sensitive_attributes_wo in config. Write-only values
+// are only available in the configuration, never in the plan or state.
+func sensitiveElements(ctx context.Context, plan objectResourceModel, config tfsdk.Config, writeOnly bool) (map[string]types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elements := make(map[string]types.String, len(plan.SensitiveAttributes.Elements()))
+	diags.Append(plan.SensitiveAttributes.ElementsAs(ctx, &elements, false)...)
+	if !writeOnly {
+		return elements, diags
+	}
+
+	var configWo types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("sensitive_attributes_wo"), &configWo)...)
+	woElements := make(map[string]types.String, len(configWo.Elements()))
+	diags.Append(configWo.ElementsAs(ctx, &woElements, false)...)
+	maps.Copy(elements, woElements)
+	return elements, diags
+}
+
+// writeOnlyAttributeNames returns write_only_attribute_names for the
+// sensitive_attributes_wo in config.
+func writeOnlyAttributeNames(ctx context.Context, config tfsdk.Config) (types.List, diag.Diagnostics) {
+	var configWo types.Map
+	diags := config.GetAttribute(ctx, path.Root("sensitive_attributes_wo"), &configWo)
+	if diags.HasError() || configWo.IsNull() {
+		return types.ListNull(types.StringType), diags
+	}
+	if configWo.IsUnknown() {
+		return types.ListUnknown(types.StringType), diags
+	}
+	names := slices.Sorted(maps.Keys(configWo.Elements()))
+	list, d := types.ListValueFrom(ctx, types.StringType, names)
+	diags.Append(d...)
+	return list, diags
+}
+
+// hiddenAttributeNames returns the names of the attributes kept out of
+// attributes and all_attributes because their values are sensitive.
+func hiddenAttributeNames(ctx context.Context, state objectResourceModel) []string {
+	names := slices.Collect(maps.Keys(state.SensitiveAttributes.Elements()))
+	var writeOnly []string
+	state.WriteOnlyAttributeNames.ElementsAs(ctx, &writeOnly, false)
+	return append(names, writeOnly...)
+}
+
+// maskSensitiveValues hides the given values in every log entry written with
+// the returned context, including the response bodies logged for API errors.
+func maskSensitiveValues(ctx context.Context, elements map[string]types.String) context.Context {
+	values := sensitiveValues(elements)
+	if len(values) == 0 {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, values...)
+}
+
+// sensitiveValues returns the values of elements that are hidden in logs and
+// diagnostics.
+func sensitiveValues(elements map[string]types.String) []string {
+	var values []string
+	for _, value := range elements {
+		if value.ValueString() != "" {
+			values = append(values, value.ValueString())
 		}
 	}
-	return "", fmt.Errorf("unknown status, available statuses: " + strings.Join(statuses, ","))
+	return values
 }
 
-func getConfigStatusNameByID(id string, statusType *[]StatusTypeMetadata) string {
-	for _, statusType := range *statusType {
-		if statusType.ID == id {
-			return statusType.Name
+// configuredAttributePath returns where errors of an attribute are reported:
+// in attributes, sensitive_attributes or sensitive_attributes_wo, depending
+// on where it is configured.
+func configuredAttributePath(plan objectResourceModel, sensitive map[string]types.String) func(name string) path.Path {
+	return func(name string) path.Path {
+		if _, ok := plan.SensitiveAttributes.Elements()[name]; ok {
+			return path.Root("sensitive_attributes").AtMapKey(name)
 		}
+		if _, ok := plan.Attributes.Elements()[name]; !ok {
+			if _, ok := sensitive[name]; ok {
+				return path.Root("sensitive_attributes_wo").AtMapKey(name)
+			}
+		}
+		return path.Root("attributes").AtMapKey(name)
 	}
-	return ""
+}
+
+// applyAvatar uploads the image of avatar_file or avatar_base64 unless it is
+// the one uploaded before, and sets avatar_uuid and avatar_hash.
+func (r *objectResource) applyAvatar(ctx context.Context, plan *objectResourceModel, priorHash types.String) error {
+	content, filename, err := avatarContent(plan.AvatarFile, plan.AvatarBase64)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		plan.AvatarHash = types.StringNull()
+		if plan.AvatarUuid.IsUnknown() {
+			plan.AvatarUuid = types.StringNull()
+		}
+		return nil
+	}
+
+	hash := contentHash(content)
+	plan.AvatarHash = types.StringValue(hash)
+	if hash == priorHash.ValueString() && !plan.AvatarUuid.IsUnknown() && !plan.AvatarUuid.IsNull() {
+		return nil
+	}
+
+	tflog.Info(ctx, "Uploading object avatar.", map[string]interface{}{
+		"hash": hash,
+		"size": len(content),
+	})
+	uuid, err := uploadAvatar(ctx, r.client, r.workspaceId, filename, content)
+	if err != nil {
+		return err
+	}
+	plan.AvatarUuid = types.StringValue(uuid)
+	plan.HasAvatar = types.BoolValue(true)
+	return nil
+}
+
+// allAttributes fetches the attributes of an object for all_attributes,
+// leaving out the hidden attributes.
+func (r *objectResource) allAttributes(ctx context.Context, metadata *schemaMetadata, id string, hidden []string) (types.Map, error) {
+	attrs, response, err := r.client.Object.Attributes(ctx, r.workspaceId, id)
+	if err != nil {
+		if response != nil {
+			return types.MapNull(types.StringType), fmt.Errorf("%w: %s", err, response.Bytes.String())
+		}
+		return types.MapNull(types.StringType), err
+	}
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		return types.MapNull(types.StringType), err
+	}
+	values := allObjectAttributeValues(attrs, index)
+	for _, name := range hidden {
+		delete(values, name)
+	}
+	allAttributes, diags := types.MapValueFrom(ctx, types.StringType, values)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), fmt.Errorf("unable to convert attributes: %v", diags)
+	}
+	return allAttributes, nil
+}
+
+// postChangeComment posts the change_comment of plan with the Terraform run
+// context. The object is already changed at this point, so a failure only
+// warns.
+func (r *objectResource) postChangeComment(ctx context.Context, plan objectResourceModel, action string, changed []string, diags *diag.Diagnostics) {
+	if plan.ChangeComment.IsNull() || plan.ChangeComment.ValueString() == "" {
+		return
+	}
+
+	body := changeCommentBody(plan.ChangeComment.ValueString(), action, changed)
+	if _, response, err := createObjectComment(ctx, r.client, r.workspaceId, plan.Id.ValueString(), body, 0); err != nil {
+		for _, d := range apiErrorDiagnostics(ctx, "Unable to post change comment", err, response, nil) {
+			diags.AddAttributeWarning(path.Root("change_comment"), d.Summary(), d.Detail())
+		}
+	}
+}
+
+// changedAttributes returns the sorted names of the attributes whose planned
+// values differ from the prior state.
+func changedAttributes(prior map[string]types.String, planned map[string]types.String) []string {
+	var changed []string
+	for name, value := range planned {
+		if previous, ok := prior[name]; !ok || !previous.Equal(value) {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// findAdoptableObject searches for an existing object of the planned type
+// whose adopt_by attributes have the planned values. It returns nil when
+// adopt_by is not set or no object matches.
+func (r *objectResource) findAdoptableObject(ctx context.Context, objectType *models.ObjectTypeScheme, adoptBy types.List, elements map[string]types.String) (*models.ObjectScheme, error) {
+	if adoptBy.IsNull() || adoptBy.IsUnknown() || len(adoptBy.Elements()) == 0 {
+		return nil, nil
+	}
+
+	var names []string
+	if diags := adoptBy.ElementsAs(ctx, &names, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read adopt_by: %v", diags)
+	}
+
+	clauses := []string{"objectTypeId = " + objectType.Id}
+	for _, name := range names {
+		value, ok := elements[name]
+		if !ok || value.IsNull() || value.IsUnknown() {
+			return nil, fmt.Errorf("attribute %q is listed in adopt_by but has no value in attributes", name)
+		}
+		clauses = append(clauses, aqlQuote(name)+" = "+aqlQuote(value.ValueString()))
+	}
+	aql := strings.Join(clauses, " AND ")
+
+	objects, total, err := filterObjects(ctx, r.client, r.workspaceId, aql, 10)
+	if err != nil {
+		return nil, err
+	}
+	switch total {
+	case 0:
+		return nil, nil
+	case 1:
+		return objects[0], nil
+	}
+
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, object.ObjectKey)
+	}
+	if total > len(keys) {
+		keys = append(keys, "...")
+	}
+	return nil, fmt.Errorf("%d %s objects match %s (%s), adopt_by must identify a single object", total, objectType.Name, strings.Join(names, ", "), strings.Join(keys, ", "))
 }
 
 // Schema defines the schema for the resource.
-func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *objectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "A Jira Assets object resource.",
+		// version 0 referenced the object type and attributes by ID, see
+		// UpgradeState
+		Version: 1,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 		Attributes: map[string]schema.Attribute{
 			"workspace_id": schema.StringAttribute{
 				Computed:    true,
@@ -187,6 +568,15 @@ func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"object_schema_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the object schema the object belongs to. Defaults to the object_schema_id of the provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"type": schema.StringAttribute{
 				Required: true,
 			},
@@ -209,9 +599,108 @@ func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed: true,
 				Default:  booldefault.StaticBool(false),
 			},
+			"all_attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "All attributes of the object, including the ones set by other tools and the ones not tracked in attributes. References are given as object keys, statuses by name and multiple values are separated by commas.",
+			},
+			"adopt_by": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Names of attributes that identify an existing object, e.g. [\"Name\"]. On create, an object of the same type whose attributes have the configured values is updated and taken into state instead of creating a duplicate. It is an error if several objects match.",
+			},
 			"avatar_uuid": schema.StringAttribute{
 				Optional:    true,
-				Description: "The UUID as retrieved by uploading an avatar.",
+				Computed:    true,
+				Description: "The UUID as retrieved by uploading an avatar. Set automatically when avatar_file or avatar_base64 is used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"avatar_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of an image to upload as the avatar of the object. Conflicts with avatar_base64 and avatar_uuid.",
+			},
+			"avatar_base64": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base64 encoded image to upload as the avatar of the object. Conflicts with avatar_file and avatar_uuid.",
+			},
+			"avatar_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the image uploaded from avatar_file or avatar_base64, used to upload it again when it changes.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ignore_attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Names of attributes that are not tracked in the state of this object, in addition to the ignore_keys of the provider. Use it for attributes maintained by other tools. They cannot be set in attributes.",
+			},
+			"manage_only_configured_attributes": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Only track the attributes set in attributes, none if it is empty. Other attributes of the object are left untouched and never show up in the plan.",
+			},
+			"prevent_destroy_if_open_tickets": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Fail to destroy the object while Jira issues connected to it are not done, see closed_ticket_statuses of the provider.",
+			},
+			"deletion_policy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(deletionPolicyDelete),
+				Description: "What happens to the object on destroy: \"delete\" deletes it, \"abandon\" only removes it from the state and \"set_status\" keeps it and applies deletion_status and deletion_attributes.",
+			},
+			"deletion_status": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the status set on the status attribute of the object when it is destroyed with the set_status deletion_policy, e.g. \"Retired\".",
+			},
+			"deletion_attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Attribute values set on the object when it is destroyed with the set_status deletion_policy.",
+			},
+			"sensitive_attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Key value pairs of attributes whose values are hidden in plan output and logs, e.g. license keys. They are set like attributes, but are not listed in attributes or all_attributes.",
+			},
+			"sensitive_attributes_wo": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Key value pairs of attributes that are set on the object but never stored in the state. Requires Terraform 1.11 or later. The values are sent on create and whenever sensitive_attributes_wo_version or the attribute names change.",
+			},
+			"sensitive_attributes_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Change this value to send sensitive_attributes_wo again, e.g. after rotating a password.",
+			},
+			"write_only_attribute_names": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The names of the attributes set by sensitive_attributes_wo, which are not tracked in attributes or all_attributes.",
+			},
+			"change_comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "A comment posted on the object whenever Terraform creates or changes it, followed by the changed attributes and the HCP Terraform workspace and run, if any.",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *objectResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the object.",
 			},
 		},
 	}
@@ -227,23 +716,65 @@ func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	object_type_id := getObjectTypeByName(plan.Type.ValueString(), r.objectSchemaTypes)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata := r.schemaMetadata(plan.ObjectSchemaId)
+	plan.ObjectSchemaId = types.StringValue(metadata.schemaId)
+
+	object_type_id, err := metadata.objectType(ctx, plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Unknown object type",
+			err.Error(),
+		)
+		return
+	}
 
 	elements := make(map[string]types.String, len(plan.Attributes.Elements()))
 	plan.Attributes.ElementsAs(ctx, &elements, false)
 
-	var attributes []*models.ObjectPayloadAttributeScheme
-	for attr_type, attr_value := range elements {
-		v, e := returnAttributePayloadValue(attr_type, attr_value.ValueString(), object_type_id.Name, r.objectSchemaAttributes, r.configStatusType)
-		if e != nil {
-			tflog.Error(ctx, e.Error())
-			resp.Diagnostics.AddError(
-				"Error during object attributes setting",
-				e.Error(),
-			)
-			return
-		}
-		attributes = append(attributes, v)
+	sensitive, diags := sensitiveElements(ctx, plan, req.Config, true)
+	resp.Diagnostics.Append(diags...)
+	plan.WriteOnlyAttributeNames, diags = writeOnlyAttributeNames(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = maskSensitiveValues(ctx, sensitive)
+	plan.SensitiveAttributesWo = types.MapNull(types.StringType)
+
+	attributes, err := attributePayload(ctx, metadata, object_type_id.Name, mergeElements(elements, sensitive))
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Error during object attributes setting",
+			redactSecrets(err.Error(), sensitiveValues(sensitive)),
+		)
+		return
+	}
+
+	// the avatar is only uploaded once the object to create or adopt is known
+	existing, err := r.findAdoptableObject(ctx, object_type_id, plan.AdoptBy, elements)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("adopt_by"),
+			"Unable to adopt existing object",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.applyAvatar(ctx, &plan, types.StringNull()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object avatar upload",
+			err.Error(),
+		)
+		return
 	}
 
 	// create payload
@@ -254,21 +785,21 @@ func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest,
 		AvatarUUID:   plan.AvatarUuid.ValueString(),
 	}
 
-	object, response, err := r.client.Object.Create(ctx, r.workspaceId, payload)
+	var object *models.ObjectScheme
+	var response *models.ResponseScheme
+	if existing != nil {
+		tflog.Info(ctx, "Adopting existing object.", map[string]interface{}{
+			"Id":  existing.ID,
+			"Key": existing.ObjectKey,
+		})
+		object, response, err = r.client.Object.Update(ctx, r.workspaceId, existing.ID, payload)
+	} else {
+		object, response, err = r.client.Object.Create(ctx, r.workspaceId, payload)
+	}
 	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error creating object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
-
-		resp.Diagnostics.AddError(
-			"Error during object creation",
-			err.Error(),
-		)
+		// the metadata is loaded at this point, it resolves attribute IDs in the error
+		index, _ := metadata.indexed(ctx)
+		resp.Diagnostics.Append(apiErrorDiagnosticsAt(ctx, "Error during object creation", err, response, index, configuredAttributePath(plan, sensitive), sensitiveValues(sensitive))...)
 		return
 	}
 
@@ -281,6 +812,14 @@ func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.Created = types.StringValue(object.Created)
 	plan.Updated = types.StringValue(object.Updated)
 	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+	plan.AllAttributes, err = r.allAttributes(ctx, metadata, object.ID, hiddenAttributeNames(ctx, plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object attributes reading",
+			err.Error(),
+		)
+		return
+	}
 
 	// Set state to full populated data
 	diags = resp.State.Set(ctx, plan)
@@ -288,6 +827,15 @@ func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, objectResourceIdentityModel{Id: plan.Id})...)
+	}
+
+	action := "created"
+	if existing != nil {
+		action = "adopted"
+	}
+	r.postChangeComment(ctx, plan, action, changedAttributes(nil, mergeElements(elements, sensitive)), &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -300,54 +848,81 @@ func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Get refreshed object from Assets API
-	object, response, err := r.client.Object.Get(ctx, r.workspaceId, state.Id.ValueString())
-	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error reading object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		resp.Diagnostics.AddError(
-			"Error during object reading",
-			err.Error(),
-		)
+	// Get refreshed object and its attributes from Assets API
+	object, attrs, response, err := getObjectWithAttributes(ctx, r.client, r.workspaceId, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during object reading", err, response, nil)...)
 		return
 	}
 
-	// Get refreshed object attributes from Assets API
-	attrs, response, err := r.client.Object.Attributes(ctx, r.workspaceId, state.Id.ValueString())
+	// the object's own schema decides how its attribute values are decoded
+	objectSchemaId := state.ObjectSchemaId
+	if object.ObjectType != nil && object.ObjectType.ObjectSchemaId != "" {
+		objectSchemaId = types.StringValue(object.ObjectType.ObjectSchemaId)
+	}
+	metadata := r.schemaMetadata(objectSchemaId)
+	index, err := metadata.indexed(ctx)
 	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error reading object attributes: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
 		resp.Diagnostics.AddError(
-			"Error during object attributes reading",
+			"Unable to load object schema metadata",
 			err.Error(),
 		)
 		return
 	}
-	attributes := make(map[string]string)
-	for _, attr := range attrs {
-		// only map known attributes in the state, this is because the API return computed attributes like "key", "created",
-		// and "updated". CI Class in my instance also messes up the state
-		ignore_keys := append([]string{"Created", "Key", "Updated"}, r.ignoreKeys...)
-		if !(slices.Contains(ignore_keys, attr.ObjectTypeAttribute.Name)) {
-			attributes[attr.ObjectTypeAttribute.Name], _ = getAttributeValue(attr, r.configStatusType)
+
+	ignoreKeys := r.ignoreKeys
+	if !state.IgnoreAttributes.IsNull() {
+		var ignoreAttributes []string
+		resp.Diagnostics.Append(state.IgnoreAttributes.ElementsAs(ctx, &ignoreAttributes, false)...)
+		ignoreKeys = slices.Concat(r.ignoreKeys, ignoreAttributes)
+	}
+	// sensitive attributes are only tracked in sensitive_attributes, and
+	// write-only ones not at all
+	hidden := hiddenAttributeNames(ctx, state)
+	attributes := objectAttributeValues(attrs, index, slices.Concat(ignoreKeys, hidden))
+	if !state.SensitiveAttributes.IsNull() {
+		values := objectAttributeValues(attrs, index, nil)
+		sensitive := make(map[string]string, len(state.SensitiveAttributes.Elements()))
+		for name := range state.SensitiveAttributes.Elements() {
+			if value, ok := values[name]; ok {
+				sensitive[name] = value
+			}
+		}
+		sensitiveAttributes, diags := types.MapValueFrom(ctx, types.StringType, sensitive)
+		resp.Diagnostics.Append(diags...)
+		state.SensitiveAttributes = sensitiveAttributes
+	}
+	switch {
+	case !state.ManageOnlyConfiguredAttributes.ValueBool():
+	case state.Attributes.IsNull():
+		// an imported object has no configured attributes yet and tracks
+		// everything
+	default:
+		// keep the attributes of the last applied configuration only, even
+		// if none are configured
+		configured := state.Attributes.Elements()
+		for name := range attributes {
+			if _, ok := configured[name]; !ok {
+				delete(attributes, name)
+			}
 		}
 	}
 	mapValue, _ := types.MapValueFrom(ctx, types.StringType, attributes)
+	allValues := allObjectAttributeValues(attrs, index)
+	for _, name := range hidden {
+		delete(allValues, name)
+	}
+	allAttributes, diags := types.MapValueFrom(ctx, types.StringType, allValues)
+	resp.Diagnostics.Append(diags...)
 	// Overwrite items in state with refreshed values
 	state.Attributes = mapValue
+	state.AllAttributes = allAttributes
 	state.WorkspaceId = types.StringValue(object.WorkspaceId)
 	state.GlobalId = types.StringValue(object.GlobalId)
 	state.Id = types.StringValue(object.ID)
@@ -355,12 +930,16 @@ func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.ObjectKey = types.StringValue(object.ObjectKey)
 	state.HasAvatar = types.BoolValue(object.HasAvatar)
 	state.Type = types.StringValue(object.ObjectType.Name)
+	state.ObjectSchemaId = types.StringValue(metadata.schemaId)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, objectResourceIdentityModel{Id: state.Id})...)
+	}
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -373,25 +952,72 @@ func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	object_type_id := getObjectTypeByName(plan.Type.ValueString(), r.objectSchemaTypes)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata := r.schemaMetadata(plan.ObjectSchemaId)
+	plan.ObjectSchemaId = types.StringValue(metadata.schemaId)
+
+	object_type_id, err := metadata.objectType(ctx, plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Unknown object type",
+			err.Error(),
+		)
+		return
+	}
 
 	// Generate API request body from plan
 	// if an attribute is removed from plan, it will not be removed from the object
 	// this is due to how the API only partially updates the object
 	elements := make(map[string]types.String, len(plan.Attributes.Elements()))
 	plan.Attributes.ElementsAs(ctx, &elements, false)
-	var attributes []*models.ObjectPayloadAttributeScheme
-	for attr_type, attr_value := range elements {
-		v, e := returnAttributePayloadValue(attr_type, attr_value.ValueString(), object_type_id.Name, r.objectSchemaAttributes, r.configStatusType)
-		if e != nil {
-			tflog.Error(ctx, e.Error())
-			resp.Diagnostics.AddError(
-				"Error during object attributes setting",
-				e.Error(),
-			)
-			return
-		}
-		attributes = append(attributes, v)
+
+	var prior objectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	plan.WriteOnlyAttributeNames, diags = writeOnlyAttributeNames(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// write-only values are not in the state to compare with, they are only
+	// sent again when their version or names change
+	writeOnly := !plan.SensitiveAttributesWoVersion.Equal(prior.SensitiveAttributesWoVersion) ||
+		!plan.WriteOnlyAttributeNames.Equal(prior.WriteOnlyAttributeNames)
+	sensitive, diags := sensitiveElements(ctx, plan, req.Config, writeOnly)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = maskSensitiveValues(ctx, sensitive)
+	plan.SensitiveAttributesWo = types.MapNull(types.StringType)
+
+	attributes, err := attributePayload(ctx, metadata, object_type_id.Name, mergeElements(elements, sensitive))
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Error during object attributes setting",
+			redactSecrets(err.Error(), sensitiveValues(sensitive)),
+		)
+		return
+	}
+
+	priorElements := make(map[string]types.String, len(prior.Attributes.Elements()))
+	prior.Attributes.ElementsAs(ctx, &priorElements, false)
+	priorSensitive := make(map[string]types.String, len(prior.SensitiveAttributes.Elements()))
+	prior.SensitiveAttributes.ElementsAs(ctx, &priorSensitive, false)
+
+	priorAvatarHash := prior.AvatarHash
+	if err := r.applyAvatar(ctx, &plan, priorAvatarHash); err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object avatar upload",
+			err.Error(),
+		)
+		return
 	}
 
 	// create payload
@@ -408,19 +1034,9 @@ func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest,
 	})
 	object, response, err := r.client.Object.Update(ctx, r.workspaceId, plan.Id.ValueString(), payload)
 	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error updating object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
-		}
-
-		resp.Diagnostics.AddError(
-			"Error during object update",
-			err.Error(),
-		)
+		// the metadata is loaded at this point, it resolves attribute IDs in the error
+		index, _ := metadata.indexed(ctx)
+		resp.Diagnostics.Append(apiErrorDiagnosticsAt(ctx, "Error during object update", err, response, index, configuredAttributePath(plan, sensitive), sensitiveValues(sensitive))...)
 		return
 	}
 
@@ -433,12 +1049,32 @@ func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.Created = types.StringValue(object.Created)
 	plan.Updated = types.StringValue(object.Updated)
 	plan.HasAvatar = types.BoolValue(object.HasAvatar)
+	plan.AllAttributes, err = r.allAttributes(ctx, metadata, object.ID, hiddenAttributeNames(ctx, plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object attributes reading",
+			err.Error(),
+		)
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, objectResourceIdentityModel{Id: plan.Id})...)
+	}
+
+	// write-only values sent again have no prior value and count as changed
+	changed := changedAttributes(mergeElements(priorElements, priorSensitive), mergeElements(elements, sensitive))
+	if !priorAvatarHash.Equal(plan.AvatarHash) {
+		changed = append(changed, "avatar")
+	}
+	if len(changed) > 0 {
+		r.postChangeComment(ctx, plan, "updated", changed, &resp.Diagnostics)
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -451,28 +1087,400 @@ func (r *objectResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch state.DeletionPolicy.ValueString() {
+	case deletionPolicyAbandon:
+		tflog.Info(ctx, "Abandoning object, it is only removed from state.", map[string]interface{}{
+			"Id": state.Id.ValueString(),
+		})
+		return
+	case deletionPolicySetStatus:
+		resp.Diagnostics.Append(r.retireObject(ctx, state)...)
+		return
+	}
+
+	if state.PreventDestroyIfOpenTickets.ValueBool() {
+		page, response, err := connectedTickets(ctx, r.client, r.workspaceId, state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Unable to check connected tickets", err, response, nil)...)
+			return
+		}
+		var open []string
+		for _, ticket := range page.Tickets {
+			if ticketIsOpen(ticket, r.closedTicketStatuses) {
+				open = append(open, ticket.Key)
+			}
+		}
+		if len(open) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prevent_destroy_if_open_tickets"),
+				"Object has open tickets",
+				fmt.Sprintf("Object %s is connected to open Jira issues %s. Resolve them or set prevent_destroy_if_open_tickets to false.", state.ObjectKey.ValueString(), strings.Join(open, ", ")),
+			)
+			return
+		}
+	}
+
 	// Delete existing object
 	response, err := r.client.Object.Delete(ctx, r.workspaceId, state.Id.ValueString())
 	if err != nil {
-		if response != nil {
-			tflog.Error(ctx, "Error deleting object: %s", map[string]interface{}{
-				"url":         response.Request.URL,
-				"status_code": response.StatusCode,
-				"headers":     response.Header,
-				"body":        response.Body,
-			})
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error during object deletion", err, response, nil)...)
+		return
+	}
+}
+
+// retireObject applies deletion_status and deletion_attributes instead of
+// deleting the object, for the set_status deletion_policy.
+func (r *objectResource) retireObject(ctx context.Context, state objectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	metadata := r.schemaMetadata(state.ObjectSchemaId)
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		diags.AddError("Error during object retirement", err.Error())
+		return diags
+	}
+	objectType, err := metadata.objectType(ctx, state.Type.ValueString())
+	if err != nil {
+		diags.AddError("Error during object retirement", err.Error())
+		return diags
+	}
+
+	elements := make(map[string]types.String, len(state.DeletionAttributes.Elements()))
+	state.DeletionAttributes.ElementsAs(ctx, &elements, false)
+	statusName := ""
+	if !state.DeletionStatus.IsNull() {
+		statusAttr, err := statusAttribute(index, objectType.Name)
+		if err != nil {
+			diags.AddError("Error during object retirement", err.Error())
+			return diags
 		}
+		statusName = statusAttr.Name
+		elements[statusName] = state.DeletionStatus
+	}
+
+	attributes, err := attributePayload(ctx, metadata, objectType.Name, elements)
+	if err != nil {
+		diags.AddError("Error during object retirement", err.Error())
+		return diags
+	}
+
+	tflog.Info(ctx, "Retiring object instead of deleting it.", map[string]interface{}{
+		"Id": state.Id.ValueString(),
+	})
+	_, response, err := r.client.Object.Update(ctx, r.workspaceId, state.Id.ValueString(), &models.ObjectPayloadScheme{
+		ObjectTypeID: objectType.Id,
+		Attributes:   attributes,
+		HasAvatar:    state.HasAvatar.ValueBool(),
+		AvatarUUID:   state.AvatarUuid.ValueString(),
+	})
+	if err != nil {
+		return apiErrorDiagnosticsAt(ctx, "Error during object retirement", err, response, index, func(name string) path.Path {
+			if name == statusName {
+				return path.Root("deletion_status")
+			}
+			return path.Root("deletion_attributes").AtMapKey(name)
+		}, nil)
+	}
+	return nil
+}
+
+// statusAttribute returns the only status attribute of an object type.
+func statusAttribute(index *metadataIndex, objectType string) (*models.ObjectTypeAttributeScheme, error) {
+	var found []*models.ObjectTypeAttributeScheme
+	for key, attr := range index.attributesByName {
+		if key.objectType == objectType && attr.Type == 7 {
+			found = append(found, attr)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("object type %s has no status attribute, set the status with deletion_attributes", objectType)
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, 0, len(found))
+	for _, attr := range found {
+		names = append(names, attr.Name)
+	}
+	slices.Sort(names)
+	return nil, fmt.Errorf("object type %s has several status attributes (%s), set the status with deletion_attributes", objectType, strings.Join(names, ", "))
+}
+
+// ModifyPlan fills in the provider's object schema when the resource does not
+// set its own, so the plan shows the schema the object will be created in.
+func (r *objectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.metadata == nil {
+		return
+	}
+
+	var configSchemaId, planSchemaId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("object_schema_id"), &configSchemaId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("object_schema_id"), &planSchemaId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configSchemaId.IsNull() && planSchemaId.IsUnknown() && r.objectschemaId != "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("object_schema_id"), types.StringValue(r.objectschemaId))...)
+	}
 
+	// the names of write-only attributes are known from the configuration,
+	// a changed name shows up in the plan
+	names, diags := writeOnlyAttributeNames(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("write_only_attribute_names"), names)...)
+
+	r.modifyAvatarPlan(ctx, req, resp)
+}
+
+// modifyAvatarPlan plans avatar_hash from the configured image, so a changed
+// image shows up in the plan and is uploaded again.
+func (r *objectResource) modifyAvatarPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var avatarFile, avatarBase64, configUuid, priorHash types.String
+	var configHasAvatar types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_file"), &avatarFile)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_base64"), &avatarBase64)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_uuid"), &configUuid)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("has_avatar"), &configHasAvatar)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("avatar_hash"), &priorHash)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if avatarFile.IsUnknown() || avatarBase64.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_hash"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_uuid"), types.StringUnknown())...)
+		return
+	}
+
+	content, _, err := avatarContent(avatarFile, avatarBase64)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error during object deletion",
+			"Invalid object avatar",
 			err.Error(),
 		)
 		return
 	}
+
+	if content == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_hash"), types.StringNull())...)
+		// without an image avatar_uuid is only what the configuration says
+		if configUuid.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_uuid"), types.StringNull())...)
+		}
+		return
+	}
+
+	hash := contentHash(content)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_hash"), types.StringValue(hash))...)
+	if hash != priorHash.ValueString() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("avatar_uuid"), types.StringUnknown())...)
+	}
+	if configHasAvatar.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("has_avatar"), types.BoolValue(true))...)
+	}
 }
 
+// ValidateConfig checks that at most one avatar source is configured, that
+// every attribute is set in only one of the attribute maps, not ignored and
+// editable and that the deletion settings fit the deletion_policy.
+func (r *objectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var avatarFile, avatarBase64, avatarUuid types.String
+	var hasAvatar types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_file"), &avatarFile)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_base64"), &avatarBase64)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("avatar_uuid"), &avatarUuid)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("has_avatar"), &hasAvatar)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sources []string
+	for name, value := range map[string]types.String{
+		"avatar_file":   avatarFile,
+		"avatar_base64": avatarBase64,
+		"avatar_uuid":   avatarUuid,
+	} {
+		if !value.IsNull() {
+			sources = append(sources, name)
+		}
+	}
+	if len(sources) > 1 {
+		slices.Sort(sources)
+		resp.Diagnostics.AddAttributeError(
+			path.Root(sources[1]),
+			"Conflicting object avatar",
+			"Only one of avatar_file, avatar_base64 and avatar_uuid can be set, got "+strings.Join(sources, " and ")+".",
+		)
+	}
+
+	if (!avatarFile.IsNull() || !avatarBase64.IsNull()) && !hasAvatar.IsNull() && !hasAvatar.IsUnknown() && !hasAvatar.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("has_avatar"),
+			"Conflicting object avatar",
+			"has_avatar cannot be false when avatar_file or avatar_base64 is set.",
+		)
+	}
+
+	r.validateAttributeMaps(ctx, req, resp)
+	r.validateIgnoreAttributes(ctx, req, resp)
+	r.validateReadOnlyAttributes(ctx, req, resp)
+
+	var deletionPolicy, deletionStatus types.String
+	var deletionAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_policy"), &deletionPolicy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_status"), &deletionStatus)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_attributes"), &deletionAttributes)...)
+	if resp.Diagnostics.HasError() || deletionPolicy.IsUnknown() {
+		return
+	}
+
+	policy := deletionPolicy.ValueString()
+	switch {
+	case deletionPolicy.IsNull(), policy == deletionPolicyDelete, policy == deletionPolicyAbandon:
+		if !deletionStatus.IsNull() || !deletionAttributes.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_policy"),
+				"Invalid deletion policy",
+				"deletion_status and deletion_attributes are only used with the set_status deletion_policy.",
+			)
+		}
+	case policy == deletionPolicySetStatus:
+		if deletionStatus.IsNull() && deletionAttributes.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_policy"),
+				"Invalid deletion policy",
+				"The set_status deletion_policy requires deletion_status or deletion_attributes.",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_policy"),
+			"Invalid deletion policy",
+			fmt.Sprintf("deletion_policy must be one of %q, %q or %q, got %q.", deletionPolicyDelete, deletionPolicyAbandon, deletionPolicySetStatus, policy),
+		)
+	}
+}
+
+// validateAttributeMaps checks that attributes, sensitive_attributes and
+// sensitive_attributes_wo do not set the same attribute.
+func (r *objectResource) validateAttributeMaps(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	seen := make(map[string]string)
+	for _, name := range []string{"attributes", "sensitive_attributes", "sensitive_attributes_wo"} {
+		var elements types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &elements)...)
+		for _, key := range slices.Sorted(maps.Keys(elements.Elements())) {
+			if other, ok := seen[key]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtMapKey(key),
+					"Conflicting object attribute",
+					fmt.Sprintf("Attribute %q is set in both %s and %s.", key, other, name),
+				)
+				continue
+			}
+			seen[key] = name
+		}
+	}
+}
+
+// validateIgnoreAttributes checks that ignore_attributes does not list an
+// attribute set in attributes. Ignored attributes are never read back, so
+// configuring them too would show a difference on every plan.
+func (r *objectResource) validateIgnoreAttributes(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var attributes types.Map
+	var ignoreAttributes types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attributes"), &attributes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ignore_attributes"), &ignoreAttributes)...)
+	configured := attributes.Elements()
+	for i, element := range ignoreAttributes.Elements() {
+		name, ok := element.(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if _, ok := configured[name.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ignore_attributes").AtListIndex(i),
+				"Conflicting object attribute",
+				fmt.Sprintf("Attribute %q is set in attributes and listed in ignore_attributes.", name.ValueString()),
+			)
+		}
+	}
+}
+
+// validateReadOnlyAttributes checks that the attribute maps do not set
+// attributes Assets does not allow to edit, like Key. It needs the object
+// schema metadata, so it only runs once the provider is configured and the
+// type is known.
+func (r *objectResource) validateReadOnlyAttributes(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.metadata == nil {
+		return
+	}
+	var objectType, objectSchemaId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &objectType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("object_schema_id"), &objectSchemaId)...)
+	if resp.Diagnostics.HasError() || objectType.IsNull() || objectType.IsUnknown() || objectSchemaId.IsUnknown() {
+		return
+	}
+	index, err := r.schemaMetadata(objectSchemaId).indexed(ctx)
+	if err != nil {
+		// planning loads the metadata again and reports the error
+		return
+	}
+
+	for _, name := range []string{"attributes", "sensitive_attributes", "sensitive_attributes_wo"} {
+		var elements types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &elements)...)
+		for _, key := range slices.Sorted(maps.Keys(elements.Elements())) {
+			if index.readOnlyAttributesByName[objectAttributeKey{objectType: objectType.ValueString(), name: key}] != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtMapKey(key),
+					"Read only object attribute",
+					fmt.Sprintf("Attribute %q of object type %q is not editable in Assets and cannot be set.", key, objectType.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// ImportState accepts the numeric object ID, "key:<object key>" or
+// "aql:<query>" for a query that matches exactly one object.
 func (r *objectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var aql string
+	switch {
+	case strings.HasPrefix(req.ID, "key:"):
+		aql = "Key = " + aqlQuote(strings.TrimSpace(strings.TrimPrefix(req.ID, "key:")))
+	case strings.HasPrefix(req.ID, "aql:"):
+		aql = strings.TrimSpace(strings.TrimPrefix(req.ID, "aql:"))
+	default:
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	object, err := findSingleObject(ctx, r.client, r.workspaceId, aql)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import object",
+			"Could not resolve import ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Resolved object for import", map[string]interface{}{
+		"import_id": req.ID,
+		"id":        object.ID,
+	})
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), object.ID)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, objectResourceIdentityModel{Id: types.StringValue(object.ID)})...)
+	}
 }
 
 // Configure configures the resource with the given configuration.
@@ -494,7 +1502,6 @@ func (r *objectResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.workspaceId = providerClient.workspaceId
 	r.objectschemaId = providerClient.objectschemaId
 	r.ignoreKeys = providerClient.ignoreKeys
-	r.objectSchemaTypes = providerClient.objectSchemaTypes
-	r.objectSchemaAttributes = providerClient.objectSchemaAttributes
-	r.configStatusType = providerClient.configStatusType
+	r.closedTicketStatuses = providerClient.closedTicketStatuses
+	r.metadata = providerClient.metadata
 }
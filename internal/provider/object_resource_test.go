@@ -1,52 +1,1249 @@
 package provider
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccJiraAssetsObjectResource(t *testing.T) {
+	fake := newFakeAssets(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: `resource "jiraassets_object" "test" {
-					type_id = "117"
-					attributes = [
-						{
-							attr_type_id = "1087"
-							attr_value = "My Phone"
-						},
-						{
-							attr_type_id = "1090"
-							attr_value = "1234657890"
-						}
-					]
-				}`,
+				Config: testAccProviderConfig(fake) + `
+resource "jiraassets_object" "test" {
+  type = "Host"
+  attributes = {
+    "Name"     = "web01"
+    "Hostname" = "web01.example.com"
+    "Status"   = "Active"
+  }
+}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("jiraassets_object.test", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("jiraassets_object.test", "attributes.%", "3"),
+					resource.TestCheckResourceAttr("jiraassets_object.test", "label", "web01"),
+					resource.TestCheckResourceAttr("jiraassets_object.test", "object_schema_id", "1"),
+					resource.TestCheckResourceAttrSet("jiraassets_object.test", "object_key"),
 				),
 			},
 			{
-				Config: `resource "jiraassets_object" "test_avatar" {
-					type_id = "117"
-					attributes = [
-						{
-							attr_type_id = "1087"
-							attr_value = "My Phone"
-						},
-						{
-							attr_type_id = "1090"
-							attr_value = "1234657890"
-						}
-					]
-					has_avatar = true
-				}`,
+				ResourceName:      "jiraassets_object.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"created",
+				},
+			},
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "jiraassets_object" "test" {
+  type = "Host"
+  attributes = {
+    "Name"     = "web01"
+    "Hostname" = "web01.example.com"
+    "Status"   = "Retired"
+  }
+  has_avatar = true
+}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("jiraassets_object.test_avatar", "attributes.#", "2"),
+					resource.TestCheckResourceAttr("jiraassets_object.test", "attributes.Status", "Retired"),
+					resource.TestCheckResourceAttr("jiraassets_object.test", "has_avatar", "true"),
 				),
 			},
 		},
 	})
 }
+
+func testObjectModel(t *testing.T, objectType string, attributes map[string]string) objectResourceModel {
+	t.Helper()
+
+	return objectResourceModel{
+		Type:          types.StringValue(objectType),
+		Attributes:    types.MapValueMust(types.StringType, testStringValues(attributes)),
+		AllAttributes: types.MapUnknown(types.StringType),
+		HasAvatar:     types.BoolValue(false),
+		AdoptBy:       types.ListNull(types.StringType),
+
+		IgnoreAttributes:               types.ListNull(types.StringType),
+		ManageOnlyConfiguredAttributes: types.BoolValue(false),
+		ChangeComment:                  types.StringNull(),
+		PreventDestroyIfOpenTickets:    types.BoolValue(false),
+		DeletionPolicy:                 types.StringValue(deletionPolicyDelete),
+		DeletionStatus:                 types.StringNull(),
+		DeletionAttributes:             types.MapNull(types.StringType),
+		SensitiveAttributes:            types.MapNull(types.StringType),
+		SensitiveAttributesWo:          types.MapNull(types.StringType),
+		SensitiveAttributesWoVersion:   types.Int64Null(),
+		WriteOnlyAttributeNames:        types.ListNull(types.StringType),
+		Timeouts:                       nullTimeouts(),
+	}
+}
+
+func testStringValues(values map[string]string) map[string]attr.Value {
+	elements := make(map[string]attr.Value, len(values))
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	return elements
+}
+
+func testObjectAttributes(t *testing.T, state objectResourceModel) map[string]string {
+	t.Helper()
+
+	return testMapStrings(t, state.Attributes)
+}
+
+func testMapStrings(t *testing.T, m types.Map) map[string]string {
+	t.Helper()
+
+	attributes := map[string]string{}
+	for k, v := range m.Elements() {
+		s, ok := v.(types.String)
+		if !ok {
+			t.Fatalf("attribute %s is %T, want types.String", k, v)
+		}
+		attributes[k] = s.ValueString()
+	}
+	return attributes
+}
+
+func testAssertAttributes(t *testing.T, got map[string]string, want map[string]string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("got attributes %v, want %v", got, want)
+		return
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("attribute %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestObjectResourceLifecycle(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	application, diags := testResourceCreate(t, r, testObjectModel(t, "Application", map[string]string{
+		"Name": "billing",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create application: %v", diags)
+	}
+
+	want := map[string]string{
+		"Name":        "web01",
+		"Hostname":    "web01.example.com",
+		"Status":      "Active",
+		"Application": application.ObjectKey.ValueString(),
+	}
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", want))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	if created.Id.IsNull() || created.ObjectKey.ValueString() == "" {
+		t.Fatalf("Create did not set id and object_key: %+v", created)
+	}
+	if created.Label.ValueString() != "web01" {
+		t.Errorf("label = %q, want web01", created.Label.ValueString())
+	}
+	if created.ObjectSchemaId.ValueString() != "1" {
+		t.Errorf("object_schema_id = %q, want the provider default 1", created.ObjectSchemaId.ValueString())
+	}
+
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	// Key, Created and Updated are returned by the API but never tracked
+	testAssertAttributes(t, testObjectAttributes(t, read), want)
+	if read.Type.ValueString() != "Host" {
+		t.Errorf("type = %q, want Host", read.Type.ValueString())
+	}
+
+	want["Status"] = "Retired"
+	want["Hostname"] = "web01.internal"
+	plan := read
+	plan.Attributes = types.MapValueMust(types.StringType, testStringValues(want))
+	updated, diags := testResourceUpdate(t, r, read, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	read, _, diags = testResourceRead(t, r, updated)
+	if diags.HasError() {
+		t.Fatalf("Read after Update: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), want)
+	if values := fake.attributeValues(read.Id.ValueString(), "Status"); len(values) != 1 || values[0].Status == nil || values[0].Status.ID != "2" {
+		t.Errorf("status stored by the API = %v, want status 2", values)
+	}
+
+	if diags := testResourceDelete(t, r, read); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if _, ok := fake.objects[read.Id.ValueString()]; ok {
+		t.Error("object still exists after Delete")
+	}
+}
+
+func TestObjectResourceImport(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":   "db01",
+		"Status": "Active",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	imported, diags := testResourceImport[objectResourceModel](t, r, created.Id.ValueString())
+	if diags.HasError() {
+		t.Fatalf("ImportState: %v", diags)
+	}
+	imported.Attributes = types.MapNull(types.StringType)
+	read, _, diags := testResourceRead(t, r, imported)
+	if diags.HasError() {
+		t.Fatalf("Read after import: %v", diags)
+	}
+	if read.Type.ValueString() != "Host" || read.ObjectSchemaId.ValueString() != "1" {
+		t.Errorf("imported type %q in schema %q, want Host in 1", read.Type.ValueString(), read.ObjectSchemaId.ValueString())
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "db01", "Status": "Active"})
+}
+
+func TestObjectResourceOtherSchema(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Service", map[string]string{"Name": "checkout"})
+	plan.ObjectSchemaId = types.StringValue("2")
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create in schema 2: %v", diags)
+	}
+	if created.ObjectKey.ValueString() != "SRV-"+created.Id.ValueString() {
+		t.Errorf("object_key = %q, want an SRV key", created.ObjectKey.ValueString())
+	}
+
+	read, _, diags := testResourceRead(t, r, created)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "checkout"})
+}
+
+func TestObjectResourceUnknownNames(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	if _, diags := testResourceCreate(t, r, testObjectModel(t, "Printer", map[string]string{"Name": "p1"})); !diags.HasError() {
+		t.Error("Create with an unknown type returned no error")
+	}
+	if _, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Colour": "red"})); !diags.HasError() {
+		t.Error("Create with an unknown attribute returned no error")
+	}
+	if _, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Status": "Lost"})); !diags.HasError() {
+		t.Error("Create with an unknown status returned no error")
+	}
+	if len(fake.objects) != 0 {
+		t.Errorf("%d objects were created, want 0", len(fake.objects))
+	}
+}
+
+func TestGetAttributeValue(t *testing.T) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(1, 1, 2)
+	index := newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+
+	tests := map[string]struct {
+		attrType int
+		value    *models.ObjectTypeAssetAttributeValueScheme
+		want     string
+		wantErr  bool
+	}{
+		"default": {
+			attrType: 0,
+			value:    &models.ObjectTypeAssetAttributeValueScheme{Value: "web01", DisplayValue: "web01"},
+			want:     "web01",
+		},
+		"object reference": {
+			attrType: 1,
+			value:    &models.ObjectTypeAssetAttributeValueScheme{Value: "1001", SearchValue: "ITSM-1001"},
+			want:     "ITSM-1001",
+		},
+		"status": {
+			attrType: 7,
+			value:    &models.ObjectTypeAssetAttributeValueScheme{Status: &models.ObjectTypeAssetAttributeStatusScheme{ID: "2"}},
+			want:     "Status 1",
+		},
+		"unsupported": {
+			attrType: 2,
+			value:    &models.ObjectTypeAssetAttributeValueScheme{Value: "user"},
+			wantErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := getAttributeValue(&models.ObjectAttributeScheme{
+				ObjectTypeAttribute:   &models.ObjectTypeAttributeScheme{Type: test.attrType},
+				ObjectAttributeValues: []*models.ObjectTypeAssetAttributeValueScheme{test.value},
+			}, index)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, wantErr %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func testUpgradeObjectState(t *testing.T, r *objectResource, prior map[string]any) (objectResourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	raw, err := json.Marshal(prior)
+	if err != nil {
+		t.Fatalf("encoding version 0 state: %v", err)
+	}
+
+	ctx := context.Background()
+	upgrader := r.UpgradeState(ctx)[0]
+	resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: testResourceSchema(t, r).Schema}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: raw}}, resp)
+
+	var upgraded objectResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+	}
+	return upgraded, resp.Diagnostics
+}
+
+// testObjectStateV0 returns legacy version 0 state, which references the
+// object type and attributes by ID.
+func testObjectStateV0(id string, typeId string, attributes ...string) map[string]any {
+	prior := map[string]any{
+		"workspace_id": fakeWorkspaceId,
+		"global_id":    fakeWorkspaceId + ":" + id,
+		"id":           id,
+		"label":        "web01",
+		"object_key":   "ITSM-" + id,
+		"created":      "2024-01-01T00:00:00.000Z",
+		"updated":      "2024-01-01T00:00:00.000Z",
+		"has_avatar":   false,
+		"type_id":      typeId,
+		"avatar_uuid":  nil,
+	}
+	attrs := []map[string]string{}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		attrs = append(attrs, map[string]string{
+			"attr_type_id": attributes[i],
+			"attr_value":   attributes[i+1],
+		})
+	}
+	prior["attributes"] = attrs
+	return prior
+}
+
+func TestObjectResourceUpgradeStateV0(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	upgraded, diags := testUpgradeObjectState(t, r, testObjectStateV0("1001", "1",
+		"10", "web01",
+		"11", "web01.example.com",
+		"12", "2",
+	))
+	if diags.HasError() {
+		t.Fatalf("UpgradeState: %v", diags)
+	}
+	if upgraded.Type.ValueString() != "Host" || upgraded.ObjectSchemaId.ValueString() != "1" {
+		t.Errorf("upgraded type %q in schema %q, want Host in 1", upgraded.Type.ValueString(), upgraded.ObjectSchemaId.ValueString())
+	}
+	if upgraded.Id.ValueString() != "1001" || upgraded.ObjectKey.ValueString() != "ITSM-1001" {
+		t.Errorf("upgraded id %q and key %q, want 1001 and ITSM-1001", upgraded.Id.ValueString(), upgraded.ObjectKey.ValueString())
+	}
+	// status IDs are replaced by their names
+	testAssertAttributes(t, testObjectAttributes(t, upgraded), map[string]string{
+		"Name":     "web01",
+		"Hostname": "web01.example.com",
+		"Status":   "Retired",
+	})
+	if count := fake.requestCount("GET", "/objecttype/{id}"); count != 0 {
+		t.Errorf("object type was fetched %d times, want it resolved from the schema metadata", count)
+	}
+}
+
+func TestObjectResourceUpgradeStateV0OtherSchema(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	upgraded, diags := testUpgradeObjectState(t, r, testObjectStateV0("1002", "3", "30", "checkout"))
+	if diags.HasError() {
+		t.Fatalf("UpgradeState: %v", diags)
+	}
+	if upgraded.Type.ValueString() != "Service" || upgraded.ObjectSchemaId.ValueString() != "2" {
+		t.Errorf("upgraded type %q in schema %q, want Service in 2", upgraded.Type.ValueString(), upgraded.ObjectSchemaId.ValueString())
+	}
+	testAssertAttributes(t, testObjectAttributes(t, upgraded), map[string]string{"Name": "checkout"})
+}
+
+func TestObjectResourceUpgradeStateV0ByName(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	want := map[string]string{
+		"Name":     "web01",
+		"Hostname": "web01.example.com",
+		"Status":   "Active",
+	}
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", want))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	// the state written by releases that referenced types and attributes by
+	// name, before the schema was versioned
+	requestsBefore := fake.requestCount("GET", "")
+	upgraded, diags := testUpgradeObjectState(t, r, map[string]any{
+		"workspace_id": fakeWorkspaceId,
+		"global_id":    created.GlobalId.ValueString(),
+		"id":           created.Id.ValueString(),
+		"label":        "web01",
+		"object_key":   created.ObjectKey.ValueString(),
+		"created":      created.Created.ValueString(),
+		"updated":      created.Updated.ValueString(),
+		"has_avatar":   nil,
+		"type":         "Host",
+		"attributes":   want,
+		"avatar_uuid":  nil,
+	})
+	if diags.HasError() {
+		t.Fatalf("UpgradeState: %v", diags)
+	}
+	if fake.requestCount("GET", "") != requestsBefore {
+		t.Error("UpgradeState of state by name called the API")
+	}
+	if upgraded.Type.ValueString() != "Host" || upgraded.ObjectSchemaId.ValueString() != "1" || upgraded.Id != created.Id {
+		t.Errorf("upgraded = %+v", upgraded)
+	}
+	// attributes without a value in version 0 state get their default
+	if !upgraded.HasAvatar.Equal(types.BoolValue(false)) || !upgraded.AvatarUuid.IsNull() {
+		t.Errorf("upgraded avatar = %v %v, want the default", upgraded.HasAvatar, upgraded.AvatarUuid)
+	}
+	// attributes added after version 0 get their default as well
+	if upgraded.DeletionPolicy.ValueString() != deletionPolicyDelete || upgraded.ManageOnlyConfiguredAttributes.ValueBool() {
+		t.Errorf("upgraded state does not use the defaults: %+v", upgraded)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, upgraded), want)
+
+	read, removed, diags := testResourceRead(t, r, upgraded)
+	if diags.HasError() || removed {
+		t.Fatalf("Read after UpgradeState: removed=%t %v", removed, diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), want)
+}
+
+func TestObjectResourceUpgradeStateV0Unknown(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	if _, diags := testUpgradeObjectState(t, r, testObjectStateV0("1003", "99", "10", "web01")); !diags.HasError() {
+		t.Error("UpgradeState with an unknown type_id returned no error")
+	}
+	if _, diags := testUpgradeObjectState(t, r, testObjectStateV0("1003", "1", "30", "web01")); !diags.HasError() {
+		t.Error("UpgradeState with an attribute of another type returned no error")
+	}
+}
+
+func TestObjectResourceImportByKeyAndAQL(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	web, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":     "web01",
+		"Hostname": "web01.example.com",
+		"Status":   "Active",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	for _, name := range []string{"db01", "db02"} {
+		if _, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Name": name, "Hostname": "db.example.com"})); diags.HasError() {
+			t.Fatalf("Create %s: %v", name, diags)
+		}
+	}
+
+	for _, id := range []string{
+		"key:" + web.ObjectKey.ValueString(),
+		`aql:Hostname = "web01.example.com"`,
+		`aql: objectType = Host AND Name = "web01"`,
+	} {
+		imported, diags := testResourceImport[objectResourceModel](t, r, id)
+		if diags.HasError() {
+			t.Fatalf("ImportState %s: %v", id, diags)
+		}
+		if imported.Id.ValueString() != web.Id.ValueString() {
+			t.Errorf("ImportState %s resolved to %q, want %q", id, imported.Id.ValueString(), web.Id.ValueString())
+		}
+		imported.Attributes = types.MapNull(types.StringType)
+		read, _, diags := testResourceRead(t, r, imported)
+		if diags.HasError() {
+			t.Fatalf("Read after import %s: %v", id, diags)
+		}
+		if read.Type.ValueString() != "Host" || read.ObjectKey.ValueString() != web.ObjectKey.ValueString() {
+			t.Errorf("imported %s as %q %q", id, read.Type.ValueString(), read.ObjectKey.ValueString())
+		}
+		testAssertAttributes(t, testObjectAttributes(t, read), testObjectAttributes(t, web))
+	}
+
+	for _, id := range []string{
+		"key:ITSM-9999",
+		`aql:Hostname = "db.example.com"`,
+		"aql:",
+	} {
+		if _, diags := testResourceImport[objectResourceModel](t, r, id); !diags.HasError() {
+			t.Errorf("ImportState %s returned no error", id)
+		}
+	}
+}
+
+func TestObjectResourceAdoptBy(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	// objects created by discovery, outside of Terraform
+	discovered, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":   "web 01",
+		"Status": "Retired",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	for i := 0; i < 2; i++ {
+		if _, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Name": "db01"})); diags.HasError() {
+			t.Fatalf("Create: %v", diags)
+		}
+	}
+
+	want := map[string]string{
+		"Name":     "web 01",
+		"Hostname": "web01.example.com",
+		"Status":   "Active",
+	}
+	plan := testObjectModel(t, "Host", want)
+	plan.AdoptBy = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Name")})
+	adopted, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create with adopt_by: %v", diags)
+	}
+	if adopted.Id.ValueString() != discovered.Id.ValueString() {
+		t.Errorf("adopted object %q, want the existing object %q", adopted.Id.ValueString(), discovered.Id.ValueString())
+	}
+	if len(fake.objects) != 3 {
+		t.Errorf("%d objects exist, want 3", len(fake.objects))
+	}
+	read, _, diags := testResourceRead(t, r, adopted)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), want)
+
+	// no match creates a new object
+	plan = testObjectModel(t, "Host", map[string]string{"Name": "web02"})
+	plan.AdoptBy = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Name")})
+	if created, diags := testResourceCreate(t, r, plan); diags.HasError() {
+		t.Fatalf("Create without a match: %v", diags)
+	} else if _, ok := fake.objects[created.Id.ValueString()]; !ok || created.Id.ValueString() == discovered.Id.ValueString() {
+		t.Errorf("Create without a match returned object %q", created.Id.ValueString())
+	}
+
+	for name, adoptBy := range map[string]string{
+		"ambiguous": "Name",
+		"unset":     "Hostname",
+	} {
+		plan := testObjectModel(t, "Host", map[string]string{"Name": "db01"})
+		plan.AdoptBy = types.ListValueMust(types.StringType, []attr.Value{types.StringValue(adoptBy)})
+		if _, diags := testResourceCreate(t, r, plan); !diags.HasError() {
+			t.Errorf("Create with %s adopt_by returned no error", name)
+		}
+	}
+	if len(fake.objects) != 4 {
+		t.Errorf("%d objects exist, want 4", len(fake.objects))
+	}
+
+	// a failed adopt lookup uploads no avatar
+	plan = testObjectModel(t, "Host", map[string]string{"Name": "db01"})
+	plan.AdoptBy = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Name")})
+	plan.AvatarBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte("db image")))
+	plan.AvatarUuid = types.StringUnknown()
+	if _, diags := testResourceCreate(t, r, plan); !diags.HasError() {
+		t.Error("Create with ambiguous adopt_by and an avatar returned no error")
+	}
+	if count := fake.requestCount("POST", "/avatar/upload"); count != 0 {
+		t.Errorf("avatar was uploaded %d times before the adopt lookup failed, want 0", count)
+	}
+}
+
+func TestObjectResourceAttributeFiltering(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	// Hostname and Status stand in for attributes maintained by discovery
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{
+		"Name":     "web01",
+		"Hostname": "web01.example.com",
+		"Status":   "Active",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	state := created
+	state.IgnoreAttributes = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Hostname")})
+	read, _, diags := testResourceRead(t, r, state)
+	if diags.HasError() {
+		t.Fatalf("Read with ignore_attributes: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "web01", "Status": "Active"})
+
+	state = created
+	state.Attributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Name": "web01"}))
+	state.ManageOnlyConfiguredAttributes = types.BoolValue(true)
+	read, _, diags = testResourceRead(t, r, state)
+	if diags.HasError() {
+		t.Fatalf("Read with manage_only_configured_attributes: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "web01"})
+
+	// updates only send the configured attributes and keep the others
+	plan := read
+	plan.Attributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Name": "web02"}))
+	updated, diags := testResourceUpdate(t, r, read, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	read, _, diags = testResourceRead(t, r, updated)
+	if diags.HasError() {
+		t.Fatalf("Read after Update: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "web02"})
+	if values := fake.attributeValues(read.Id.ValueString(), "Hostname"); len(values) != 1 || values[0].Value != "web01.example.com" {
+		t.Errorf("Hostname on the object = %v, want it untouched", values)
+	}
+
+	// without configured attributes no attribute is tracked
+	state = read
+	state.Attributes = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	read, _, diags = testResourceRead(t, r, state)
+	if diags.HasError() {
+		t.Fatalf("Read without configured attributes: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{})
+
+	// an imported object tracks everything
+	state = read
+	state.Attributes = types.MapNull(types.StringType)
+	read, _, diags = testResourceRead(t, r, state)
+	if diags.HasError() {
+		t.Fatalf("Read after import: %v", diags)
+	}
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "web02", "Hostname": "web01.example.com", "Status": "Active"})
+}
+
+func TestObjectResourceIgnoreAttributesValidation(t *testing.T) {
+	r := NewObjectResource().(*objectResource)
+
+	config := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Hostname": "web01.example.com"})
+	config.IgnoreAttributes = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Status")})
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+
+	config.IgnoreAttributes = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Status"), types.StringValue("Hostname")})
+	diags := testResourceValidateConfig(t, r, config)
+	if !diags.HasError() {
+		t.Fatal("attribute set in attributes and ignore_attributes returned no error")
+	}
+	if want := "Attribute \"Hostname\" is set in attributes and listed in ignore_attributes."; diags.Errors()[0].Detail() != want {
+		t.Errorf("ValidateConfig error = %q, want %q", diags.Errors()[0].Detail(), want)
+	}
+}
+
+func TestObjectResourceReadOnlyAttributesValidation(t *testing.T) {
+	fake := newFakeAssets(t)
+	// a custom attribute that is not editable, like a computed one
+	fake.attribute("11").Editable = false
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	config := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Status": "Active"})
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+
+	config = testObjectModel(t, "Host", map[string]string{"Name": "web01", "Key": "ITSM-1"})
+	config.SensitiveAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Hostname": "web01.example.com"}))
+	diags := testResourceValidateConfig(t, r, config)
+	want := map[string]path.Path{
+		"Key":      path.Root("attributes").AtMapKey("Key"),
+		"Hostname": path.Root("sensitive_attributes").AtMapKey("Hostname"),
+	}
+	if len(diags.Errors()) != len(want) {
+		t.Fatalf("ValidateConfig errors = %v, want one per read only attribute", diags)
+	}
+	for _, d := range diags.Errors() {
+		found := false
+		for name, p := range want {
+			if d.(diag.DiagnosticWithPath).Path().Equal(p) && strings.Contains(d.Detail(), fmt.Sprintf("%q", name)) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("unexpected ValidateConfig error %v", d)
+		}
+	}
+
+	// without a configured provider the metadata is not available
+	if diags := testResourceValidateConfig(t, NewObjectResource().(*objectResource), config); diags.HasError() {
+		t.Errorf("ValidateConfig without provider: %v", diags)
+	}
+	// Create reports read only attributes without reloading the metadata
+	if _, diags := testResourceCreate(t, r, config); !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), "read only") {
+		t.Errorf("Create with read only attributes = %v", diags)
+	}
+}
+
+func TestObjectResourceAllAttributes(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Host", map[string]string{
+		"Name":   "web01",
+		"Status": "Active",
+	})
+	plan.IgnoreAttributes = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Status")})
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	want := map[string]string{
+		"Name":    "web01",
+		"Status":  "Active",
+		"Key":     created.ObjectKey.ValueString(),
+		"Created": created.Created.ValueString(),
+		"Updated": created.Updated.ValueString(),
+	}
+	testAssertAttributes(t, testMapStrings(t, created.AllAttributes), want)
+
+	read, _, diags := testResourceRead(t, r, created)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	// ignored and computed attributes are only part of all_attributes
+	testAssertAttributes(t, testObjectAttributes(t, read), map[string]string{"Name": "web01"})
+	testAssertAttributes(t, testMapStrings(t, read.AllAttributes), want)
+}
+
+func TestDecodeAttributeValue(t *testing.T) {
+	objectTypes, objectAttributes, statusTypes := testMetadata(1, 1, 2)
+	index := newMetadataIndex(objectTypes, objectAttributes, statusTypes)
+
+	tests := map[string]struct {
+		attrType int
+		values   []*models.ObjectTypeAssetAttributeValueScheme
+		want     string
+	}{
+		"default": {
+			attrType: 0,
+			values:   []*models.ObjectTypeAssetAttributeValueScheme{{Value: "web01", DisplayValue: "web01"}},
+			want:     "web01",
+		},
+		"empty": {
+			attrType: 0,
+			want:     "",
+		},
+		"object references": {
+			attrType: 1,
+			values: []*models.ObjectTypeAssetAttributeValueScheme{
+				{Value: "1001", SearchValue: "ITSM-1001"},
+				{Value: "1002", SearchValue: "ITSM-1002"},
+			},
+			want: "ITSM-1001,ITSM-1002",
+		},
+		"user": {
+			attrType: 2,
+			values:   []*models.ObjectTypeAssetAttributeValueScheme{{Value: "5b10a2844c20165700ede21g", DisplayValue: "Mia Krystof"}},
+			want:     "5b10a2844c20165700ede21g",
+		},
+		"display value only": {
+			attrType: 6,
+			values:   []*models.ObjectTypeAssetAttributeValueScheme{{DisplayValue: "ITSM"}},
+			want:     "ITSM",
+		},
+		"status": {
+			attrType: 7,
+			values:   []*models.ObjectTypeAssetAttributeValueScheme{{Status: &models.ObjectTypeAssetAttributeStatusScheme{ID: "2"}}},
+			want:     "Status 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := decodeAttributeValue(&models.ObjectAttributeScheme{
+				ObjectTypeAttribute:   &models.ObjectTypeAttributeScheme{Type: test.attrType},
+				ObjectAttributeValues: test.values,
+			}, index)
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestObjectResourceAvatar(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	avatarFile := filepath.Join(t.TempDir(), "web.png")
+	if err := os.WriteFile(avatarFile, []byte("first image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	plan.AvatarFile = types.StringValue(avatarFile)
+	plan.HasAvatar = types.BoolNull()
+	planned, diags := testResourceModifyPlan(t, r, nil, plan)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if planned.AvatarHash.ValueString() != contentHash([]byte("first image")) || !planned.AvatarUuid.IsUnknown() || !planned.HasAvatar.ValueBool() {
+		t.Errorf("planned avatar hash %s, uuid %s, has_avatar %s", planned.AvatarHash, planned.AvatarUuid, planned.HasAvatar)
+	}
+
+	created, diags := testResourceCreate(t, r, planned)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	uuid := created.AvatarUuid.ValueString()
+	if uuid == "" || !created.HasAvatar.ValueBool() {
+		t.Fatalf("Create set avatar_uuid %q and has_avatar %s", uuid, created.HasAvatar)
+	}
+	if object := fake.objects[created.Id.ValueString()]; object.Avatar == nil || object.Avatar.AvatarUUID != uuid {
+		t.Errorf("object avatar = %+v, want %s", object.Avatar, uuid)
+	}
+	if string(fake.avatars[uuid]) != "first image" {
+		t.Errorf("uploaded avatar = %q", fake.avatars[uuid])
+	}
+
+	// unchanged content keeps the uploaded avatar
+	plan = created
+	plan.HasAvatar = types.BoolNull()
+	planned, diags = testResourceModifyPlan(t, r, &created, plan)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if planned.AvatarUuid.IsUnknown() {
+		t.Error("unchanged avatar planned a new avatar_uuid")
+	}
+	if _, diags := testResourceUpdate(t, r, created, planned); diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if count := fake.requestCount("POST", "/avatar/upload"); count != 1 {
+		t.Errorf("avatar was uploaded %d times, want 1", count)
+	}
+
+	// changed content is uploaded again
+	plan.AvatarFile = types.StringNull()
+	plan.AvatarBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte("second image")))
+	planned, diags = testResourceModifyPlan(t, r, &created, plan)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	if !planned.AvatarUuid.IsUnknown() {
+		t.Error("changed avatar did not plan a new avatar_uuid")
+	}
+	updated, diags := testResourceUpdate(t, r, created, planned)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if updated.AvatarUuid.ValueString() == uuid || string(fake.avatars[updated.AvatarUuid.ValueString()]) != "second image" {
+		t.Errorf("Update set avatar_uuid %s", updated.AvatarUuid)
+	}
+}
+
+func TestObjectResourceAvatarValidation(t *testing.T) {
+	r := NewObjectResource().(*objectResource)
+
+	config := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	config.AvatarFile = types.StringValue("web.png")
+	config.AvatarUuid = types.StringValue("a-uuid")
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("avatar_file with avatar_uuid returned no error")
+	}
+
+	config.AvatarUuid = types.StringNull()
+	config.HasAvatar = types.BoolValue(false)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("avatar_file with has_avatar = false returned no error")
+	}
+
+	config.HasAvatar = types.BoolNull()
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+}
+
+func TestObjectResourceChangeComment(t *testing.T) {
+	t.Setenv("TFC_WORKSPACE_NAME", "cmdb")
+	t.Setenv("TFC_RUN_ID", "run-123")
+
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Hostname": "web01.example.com"})
+	plan.ChangeComment = types.StringValue("CHG-42")
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	comments := fake.comments[created.Id.ValueString()]
+	want := "CHG-42\n\nTerraform created this object.\nChanged attributes: Hostname, Name\nWorkspace: cmdb\nRun: run-123"
+	if len(comments) != 1 || comments[0].Comment != want {
+		t.Fatalf("comments after Create = %+v", comments)
+	}
+
+	// an update that only changes the comment posts nothing
+	plan = created
+	plan.ChangeComment = types.StringValue("CHG-43")
+	updated, diags := testResourceUpdate(t, r, created, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if comments := fake.comments[created.Id.ValueString()]; len(comments) != 1 {
+		t.Errorf("unchanged object was commented %d times", len(comments))
+	}
+
+	plan = updated
+	plan.Attributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Name": "web01", "Hostname": "web01.internal"}))
+	if _, diags := testResourceUpdate(t, r, updated, plan); diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	comments = fake.comments[created.Id.ValueString()]
+	want = "CHG-43\n\nTerraform updated this object.\nChanged attributes: Hostname\nWorkspace: cmdb\nRun: run-123"
+	if len(comments) != 2 || comments[1].Comment != want {
+		t.Errorf("comments after Update = %+v", comments)
+	}
+}
+
+func TestObjectResourceDeletionPolicy(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	abandoned := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Status": "Active"})
+	abandoned.DeletionPolicy = types.StringValue(deletionPolicyAbandon)
+	abandoned.PreventDestroyIfOpenTickets = types.BoolValue(true)
+	created, diags := testResourceCreate(t, r, abandoned)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	fake.addTicket(created.Id.ValueString(), "INC-7", "In Progress", "yellow")
+	if diags := testResourceDelete(t, r, created); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if _, ok := fake.objects[created.Id.ValueString()]; !ok {
+		t.Error("abandoned object was deleted")
+	}
+
+	retired := testObjectModel(t, "Host", map[string]string{"Name": "web02", "Hostname": "web02.example.com", "Status": "Active"})
+	retired.DeletionPolicy = types.StringValue(deletionPolicySetStatus)
+	retired.DeletionStatus = types.StringValue("Retired")
+	retired.DeletionAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Hostname": ""}))
+	created, diags = testResourceCreate(t, r, retired)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	if diags := testResourceDelete(t, r, created); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if _, ok := fake.objects[created.Id.ValueString()]; !ok {
+		t.Fatal("retired object was deleted")
+	}
+	if values := fake.attributeValues(created.Id.ValueString(), "Status"); len(values) != 1 || values[0].Status == nil || values[0].Status.Name != "Retired" {
+		t.Errorf("status after Delete = %v, want Retired", values)
+	}
+	if values := fake.attributeValues(created.Id.ValueString(), "Hostname"); len(values) != 1 || values[0].Value != "" {
+		t.Errorf("hostname after Delete = %v, want it cleared", values)
+	}
+	if values := fake.attributeValues(created.Id.ValueString(), "Name"); len(values) != 1 || values[0].Value != "web02" {
+		t.Errorf("name after Delete = %v, want it untouched", values)
+	}
+
+	// values rejected by Assets are reported where they are configured
+	created, diags = testResourceCreate(t, r, retired)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	fake.rejected = map[string]string{"11": "Hostname must not be empty", "12": "Status Retired is not allowed"}
+	diags = testResourceDelete(t, r, created)
+	fake.rejected = nil
+	if !diags.HasError() {
+		t.Fatal("Delete with rejected values returned no error")
+	}
+	var paths []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path().String())
+		}
+	}
+	if want := []string{`deletion_attributes["Hostname"]`, "deletion_status"}; !slices.Equal(paths, want) {
+		t.Errorf("errors reported at %v, want %v", paths, want)
+	}
+
+	// Application has no status attribute
+	noStatus := testObjectModel(t, "Application", map[string]string{"Name": "billing"})
+	noStatus.DeletionPolicy = types.StringValue(deletionPolicySetStatus)
+	noStatus.DeletionStatus = types.StringValue("Retired")
+	created, diags = testResourceCreate(t, r, noStatus)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	if diags := testResourceDelete(t, r, created); !diags.HasError() {
+		t.Error("deletion_status on an object type without status attribute returned no error")
+	}
+}
+
+func TestObjectResourceDeletionPolicyValidation(t *testing.T) {
+	r := NewObjectResource().(*objectResource)
+
+	config := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	config.HasAvatar = types.BoolNull()
+	config.DeletionPolicy = types.StringValue("archive")
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("unknown deletion_policy returned no error")
+	}
+
+	config.DeletionPolicy = types.StringValue(deletionPolicySetStatus)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("set_status without deletion_status returned no error")
+	}
+
+	config.DeletionStatus = types.StringValue("Retired")
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+
+	config.DeletionPolicy = types.StringValue(deletionPolicyAbandon)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("deletion_status with abandon returned no error")
+	}
+}
+
+func TestObjectResourceTimeouts(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	// load the metadata before the API gets slow
+	if _, diags := testResourceCreate(t, r, testObjectModel(t, "Host", map[string]string{"Name": "web01"})); diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web02"})
+	plan.Timeouts = timeouts.Value{Object: types.ObjectValueMust(nullTimeouts().AttributeTypes(context.Background()), map[string]attr.Value{
+		"create": types.StringValue("50ms"),
+		"read":   types.StringNull(),
+		"update": types.StringNull(),
+		"delete": types.StringNull(),
+	})}
+	fake.latency = 200 * time.Millisecond
+	start := time.Now()
+	_, diags := testResourceCreate(t, r, plan)
+	if !diags.HasError() {
+		t.Fatal("Create slower than its timeout returned no error")
+	}
+	if elapsed := time.Since(start); elapsed >= fake.latency {
+		t.Errorf("Create returned after %s, want the 50ms timeout", elapsed)
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), context.DeadlineExceeded.Error()) {
+		t.Errorf("Create error = %v, want %s", diags, context.DeadlineExceeded)
+	}
+}
+
+func TestObjectResourceSensitiveAttributes(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.addAttribute(fake.objectType("1"), "14", "License Key", 0, false)
+	fake.addAttribute(fake.objectType("1"), "15", "Password", 0, false)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	plan := testObjectModel(t, "Host", map[string]string{"Name": "web01"})
+	plan.SensitiveAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"License Key": "AAAA-BBBB"}))
+	plan.SensitiveAttributesWo = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Password": "hunter2"}))
+	plan.SensitiveAttributesWoVersion = types.Int64Value(1)
+	plan.WriteOnlyAttributeNames = types.ListUnknown(types.StringType)
+	created, diags := testResourceCreate(t, r, plan)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	id := created.Id.ValueString()
+	if values := fake.attributeValues(id, "Password"); len(values) != 1 || values[0].Value != "hunter2" {
+		t.Errorf("stored Password = %+v", values)
+	}
+	if !created.SensitiveAttributesWo.IsNull() {
+		t.Errorf("sensitive_attributes_wo stored in state: %s", created.SensitiveAttributesWo)
+	}
+	if names := created.WriteOnlyAttributeNames.String(); names != `["Password"]` {
+		t.Errorf("write_only_attribute_names = %s", names)
+	}
+	for _, m := range []types.Map{created.Attributes, created.AllAttributes} {
+		if all := testMapStrings(t, m); all["License Key"] != "" || all["Password"] != "" {
+			t.Errorf("sensitive value tracked in %v", all)
+		}
+	}
+
+	// drift of a sensitive value is read back into sensitive_attributes only
+	fake.attributeValues(id, "License Key")[0].Value = "CCCC-DDDD"
+	read, _, diags := testResourceRead(t, r, created)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	if got := testMapStrings(t, read.SensitiveAttributes); got["License Key"] != "CCCC-DDDD" {
+		t.Errorf("sensitive_attributes after Read = %v", got)
+	}
+	if got := testObjectAttributes(t, read); len(got) != 1 || got["Name"] != "web01" {
+		t.Errorf("attributes after Read = %v", got)
+	}
+	if _, ok := testMapStrings(t, read.AllAttributes)["Password"]; ok {
+		t.Error("write-only value tracked in all_attributes")
+	}
+
+	// write-only values are only sent again with a new version
+	plan = read
+	plan.SensitiveAttributes = created.SensitiveAttributes
+	plan.SensitiveAttributesWo = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Password": "correct horse"}))
+	updated, diags := testResourceUpdate(t, r, read, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if values := fake.attributeValues(id, "Password"); values[0].Value != "hunter2" {
+		t.Errorf("Password sent without a new version: %q", values[0].Value)
+	}
+	if values := fake.attributeValues(id, "License Key"); values[0].Value != "AAAA-BBBB" {
+		t.Errorf("License Key after Update = %q", values[0].Value)
+	}
+
+	plan = updated
+	plan.SensitiveAttributesWo = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Password": "correct horse"}))
+	plan.SensitiveAttributesWoVersion = types.Int64Value(2)
+	if _, diags := testResourceUpdate(t, r, updated, plan); diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if values := fake.attributeValues(id, "Password"); values[0].Value != "correct horse" {
+		t.Errorf("Password after a new version = %q", values[0].Value)
+	}
+}
+
+func TestObjectResourceSensitiveAttributesValidation(t *testing.T) {
+	r := NewObjectResource().(*objectResource)
+
+	config := testObjectModel(t, "Host", map[string]string{"Name": "web01", "Hostname": "web01.example.com"})
+	config.AllAttributes = types.MapNull(types.StringType)
+	config.WriteOnlyAttributeNames = types.ListUnknown(types.StringType)
+	config.SensitiveAttributes = types.MapValueMust(types.StringType, testStringValues(map[string]string{"License Key": "AAAA-BBBB"}))
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+
+	config.SensitiveAttributesWo = types.MapValueMust(types.StringType, testStringValues(map[string]string{"Hostname": "web01.internal"}))
+	diags := testResourceValidateConfig(t, r, config)
+	if !diags.HasError() {
+		t.Fatal("attribute set in attributes and sensitive_attributes_wo returned no error")
+	}
+	if want := "Attribute \"Hostname\" is set in both attributes and sensitive_attributes_wo."; diags.Errors()[0].Detail() != want {
+		t.Errorf("ValidateConfig error = %q, want %q", diags.Errors()[0].Detail(), want)
+	}
+}
+
+func TestObjectResourceReadRequests(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectResource().(*objectResource), testProviderClient(t, fake))
+
+	want := map[string]string{
+		"Name":     "web01",
+		"Hostname": "web01.example.com",
+		"Status":   "Active",
+	}
+	created, diags := testResourceCreate(t, r, testObjectModel(t, "Host", want))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	requests := func() [3]int {
+		return [3]int{
+			fake.requestCount("POST", "/object/aql"),
+			fake.requestCount("GET", "/object/{id}"),
+			fake.requestCount("GET", "/object/{id}/attributes"),
+		}
+	}
+	testRead := func(name string, wantRequests [3]int) {
+		t.Helper()
+		before := requests()
+		read, removed, diags := testResourceRead(t, r, created)
+		if diags.HasError() || removed {
+			t.Fatalf("Read %s: removed=%t %v", name, removed, diags)
+		}
+		testAssertAttributes(t, testObjectAttributes(t, read), want)
+		if read.Type.ValueString() != "Host" || read.ObjectKey != created.ObjectKey || read.Label.ValueString() != "web01" {
+			t.Errorf("Read %s = %+v", name, read)
+		}
+		if all := testMapStrings(t, read.AllAttributes); all["Key"] != created.ObjectKey.ValueString() || all["Updated"] != created.Updated.ValueString() {
+			t.Errorf("Read %s all_attributes = %v, want the system attributes", name, all)
+		}
+		after := requests()
+		for i := range after {
+			after[i] -= before[i]
+		}
+		if after != wantRequests {
+			t.Errorf("Read %s made [aql, object, attributes] requests %v, want %v", name, after, wantRequests)
+		}
+	}
+
+	// one AQL search returns the object with its attributes
+	testRead("with AQL", [3]int{1, 0, 0})
+
+	fake.aqlUnavailable = true
+	testRead("without AQL", [3]int{1, 1, 1})
+
+	// an object AQL does not find is looked up with the object endpoint,
+	// which reports whether it is gone
+	fake.aqlUnavailable = false
+	delete(fake.objects, created.Id.ValueString())
+	before := requests()
+	if _, removed, diags := testResourceRead(t, r, created); !diags.HasError() && !removed {
+		t.Error("Read of a deleted object returned no error")
+	}
+	if n := requests()[1] - before[1]; n != 1 {
+		t.Errorf("Read of a deleted object made %d object requests, want 1", n)
+	}
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.ResourceWithUpgradeState = &objectResource{}
+
+// objectResourceModelV0 describes the legacy state of schema version 0,
+// which referenced the object type and its attributes by ID.
+type objectResourceModelV0 struct {
+	WorkspaceId types.String                `tfsdk:"workspace_id"`
+	GlobalId    types.String                `tfsdk:"global_id"`
+	Id          types.String                `tfsdk:"id"`
+	Label       types.String                `tfsdk:"label"`
+	ObjectKey   types.String                `tfsdk:"object_key"`
+	Created     types.String                `tfsdk:"created"`
+	Updated     types.String                `tfsdk:"updated"`
+	HasAvatar   types.Bool                  `tfsdk:"has_avatar"`
+	TypeId      types.String                `tfsdk:"type_id"`
+	Attributes  []objectAttrResourceModelV0 `tfsdk:"attributes"`
+	AvatarUuid  types.String                `tfsdk:"avatar_uuid"`
+}
+
+type objectAttrResourceModelV0 struct {
+	AttrTypeId types.String `tfsdk:"attr_type_id"`
+	AttrValue  types.String `tfsdk:"attr_value"`
+}
+
+func objectResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"workspace_id": schema.StringAttribute{Computed: true},
+			"global_id":    schema.StringAttribute{Computed: true},
+			"id":           schema.StringAttribute{Computed: true},
+			"label":        schema.StringAttribute{Computed: true},
+			"object_key":   schema.StringAttribute{Computed: true},
+			"created":      schema.StringAttribute{Computed: true},
+			"updated":      schema.StringAttribute{Computed: true},
+			"has_avatar":   schema.BoolAttribute{Optional: true, Computed: true},
+			"type_id":      schema.StringAttribute{Required: true},
+			"attributes": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attr_type_id": schema.StringAttribute{Required: true},
+						"attr_value":   schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"avatar_uuid": schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+// objectResourceModelV0ByName describes version 0 state written by releases
+// that already referenced the object type and its attributes by name.
+type objectResourceModelV0ByName struct {
+	WorkspaceId types.String `tfsdk:"workspace_id"`
+	GlobalId    types.String `tfsdk:"global_id"`
+	Id          types.String `tfsdk:"id"`
+	Label       types.String `tfsdk:"label"`
+	ObjectKey   types.String `tfsdk:"object_key"`
+	Created     types.String `tfsdk:"created"`
+	Updated     types.String `tfsdk:"updated"`
+	HasAvatar   types.Bool   `tfsdk:"has_avatar"`
+	Type        types.String `tfsdk:"type"`
+	Attributes  types.Map    `tfsdk:"attributes"`
+	AvatarUuid  types.String `tfsdk:"avatar_uuid"`
+}
+
+func objectResourceSchemaV0ByName() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"workspace_id": schema.StringAttribute{Computed: true},
+			"global_id":    schema.StringAttribute{Computed: true},
+			"id":           schema.StringAttribute{Computed: true},
+			"label":        schema.StringAttribute{Computed: true},
+			"object_key":   schema.StringAttribute{Computed: true},
+			"created":      schema.StringAttribute{Computed: true},
+			"updated":      schema.StringAttribute{Computed: true},
+			"has_avatar":   schema.BoolAttribute{Optional: true, Computed: true},
+			"type":         schema.StringAttribute{Required: true},
+			"attributes":   schema.MapAttribute{Required: true, ElementType: types.StringType},
+			"avatar_uuid":  schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+// UpgradeState converts state written by earlier schema versions.
+func (r *objectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 state has two shapes, so it is decoded by the upgrader
+		0: {
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+// upgradeStateV0 converts version 0 state. The legacy shape with a type_id
+// and a list of attribute IDs is resolved into names, state that already
+// uses names is carried over unchanged.
+func (r *objectResource) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || len(req.RawState.JSON) == 0 {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade object state",
+			"The prior state is not available as JSON.",
+		)
+		return
+	}
+
+	var shape struct {
+		Attributes json.RawMessage `json:"attributes"`
+	}
+	if err := json.Unmarshal(req.RawState.JSON, &shape); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade object state",
+			"The prior state is not valid JSON: "+err.Error(),
+		)
+		return
+	}
+	if attributes := bytes.TrimSpace(shape.Attributes); len(attributes) > 0 && attributes[0] == '[' {
+		r.upgradeStateV0ByID(ctx, req, resp)
+		return
+	}
+	r.upgradeStateV0ByName(ctx, req, resp)
+}
+
+// rawStateV0 decodes the prior state with the schema of its shape.
+func rawStateV0(ctx context.Context, req resource.UpgradeStateRequest, priorSchema *schema.Schema, target any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	raw, err := req.RawState.UnmarshalWithOpts(priorSchema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to upgrade object state",
+			"Unable to read the prior state: "+err.Error(),
+		)
+		return diags
+	}
+	state := tfsdk.State{Schema: *priorSchema, Raw: raw}
+	return state.Get(ctx, target)
+}
+
+// upgradeStateV0ByName carries version 0 state that already references the
+// object type and its attributes by name over to the current schema.
+func (r *objectResource) upgradeStateV0ByName(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior objectResourceModelV0ByName
+	resp.Diagnostics.Append(rawStateV0(ctx, req, objectResourceSchemaV0ByName(), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// these releases only supported objects of the provider's object schema
+	objectSchemaId := types.StringNull()
+	if r.objectschemaId != "" {
+		objectSchemaId = types.StringValue(r.objectschemaId)
+	}
+
+	tflog.Info(ctx, "Upgraded object state from version 0", map[string]interface{}{
+		"id":   prior.Id.ValueString(),
+		"type": prior.Type.ValueString(),
+	})
+
+	resp.Diagnostics.Append(setUpgradedObjectState(ctx, prior, objectSchemaId, &resp.State)...)
+}
+
+// upgradeStateV0ByID resolves the object type and attribute IDs of legacy
+// version 0 state into the names used since version 1.
+func (r *objectResource) upgradeStateV0ByID(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior objectResourceModelV0
+	resp.Diagnostics.Append(rawStateV0(ctx, req, objectResourceSchemaV0(), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.metadata == nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade object state",
+			"The provider must be configured to convert object type and attribute IDs into names.",
+		)
+		return
+	}
+
+	objectType, err := r.objectTypeByID(ctx, prior.TypeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type_id"),
+			"Unable to upgrade object state",
+			"Could not resolve object type "+prior.TypeId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	metadata := r.metadata.forSchema(objectType.ObjectSchemaId)
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to load object schema metadata",
+			err.Error(),
+		)
+		return
+	}
+
+	attributes := make(map[string]string, len(prior.Attributes))
+	for _, attr := range prior.Attributes {
+		attrSchema := index.attributesByID[attr.AttrTypeId.ValueString()]
+		if attrSchema == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("attributes"),
+				"Unable to upgrade object state",
+				"Attribute "+attr.AttrTypeId.ValueString()+" does not exist in object schema "+objectType.ObjectSchemaId+".",
+			)
+			return
+		}
+		value := attr.AttrValue.ValueString()
+		// status attributes were stored by ID, they are stored by name now
+		if attrSchema.Type == 7 {
+			if name := getConfigStatusNameByID(value, index); name != "" {
+				value = name
+			}
+		}
+		attributes[attrSchema.Name] = value
+	}
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Upgraded object state from version 0", map[string]interface{}{
+		"id":   prior.Id.ValueString(),
+		"type": objectType.Name,
+	})
+
+	byName := objectResourceModelV0ByName{
+		WorkspaceId: prior.WorkspaceId,
+		GlobalId:    prior.GlobalId,
+		Id:          prior.Id,
+		Label:       prior.Label,
+		ObjectKey:   prior.ObjectKey,
+		Created:     prior.Created,
+		Updated:     prior.Updated,
+		HasAvatar:   prior.HasAvatar,
+		Type:        types.StringValue(objectType.Name),
+		Attributes:  mapValue,
+		AvatarUuid:  prior.AvatarUuid,
+	}
+	resp.Diagnostics.Append(setUpgradedObjectState(ctx, byName, types.StringValue(objectType.ObjectSchemaId), &resp.State)...)
+}
+
+// setUpgradedObjectState stores an object referenced by name in version 0
+// state. Attributes that version 0 did not carry start out with their
+// default in the current schema, or null, so attributes added later need no
+// changes here.
+func setUpgradedObjectState(ctx context.Context, prior objectResourceModelV0ByName, objectSchemaId types.String, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+	for name, attribute := range state.Schema.GetAttributes() {
+		resourceAttribute, ok := attribute.(schema.Attribute)
+		if !ok {
+			continue
+		}
+		if value := schemaDefaultValue(ctx, resourceAttribute, &diags); value != nil {
+			diags.Append(state.SetAttribute(ctx, path.Root(name), value)...)
+		}
+	}
+
+	carried := map[string]attr.Value{
+		"workspace_id":     prior.WorkspaceId,
+		"global_id":        prior.GlobalId,
+		"id":               prior.Id,
+		"label":            prior.Label,
+		"object_key":       prior.ObjectKey,
+		"created":          prior.Created,
+		"updated":          prior.Updated,
+		"has_avatar":       prior.HasAvatar,
+		"object_schema_id": objectSchemaId,
+		"type":             prior.Type,
+		"attributes":       prior.Attributes,
+		"avatar_uuid":      prior.AvatarUuid,
+	}
+	for name, value := range carried {
+		// a null value keeps the default, e.g. has_avatar of objects that
+		// were never applied
+		if !value.IsNull() {
+			diags.Append(state.SetAttribute(ctx, path.Root(name), value)...)
+		}
+	}
+	return diags
+}
+
+// schemaDefaultValue returns the default of a resource attribute, or nil if
+// it has none.
+func schemaDefaultValue(ctx context.Context, attribute schema.Attribute, diags *diag.Diagnostics) attr.Value {
+	switch attribute := attribute.(type) {
+	case schema.BoolAttribute:
+		if attribute.Default != nil {
+			resp := &defaults.BoolResponse{}
+			attribute.Default.DefaultBool(ctx, defaults.BoolRequest{}, resp)
+			diags.Append(resp.Diagnostics...)
+			return resp.PlanValue
+		}
+	case schema.StringAttribute:
+		if attribute.Default != nil {
+			resp := &defaults.StringResponse{}
+			attribute.Default.DefaultString(ctx, defaults.StringRequest{}, resp)
+			diags.Append(resp.Diagnostics...)
+			return resp.PlanValue
+		}
+	case schema.Int64Attribute:
+		if attribute.Default != nil {
+			resp := &defaults.Int64Response{}
+			attribute.Default.DefaultInt64(ctx, defaults.Int64Request{}, resp)
+			diags.Append(resp.Diagnostics...)
+			return resp.PlanValue
+		}
+	}
+	return nil
+}
+
+// objectTypeByID looks an object type up in the provider's object schema
+// first and asks the API for types of other schemas.
+func (r *objectResource) objectTypeByID(ctx context.Context, id string) (*models.ObjectTypeScheme, error) {
+	if r.objectschemaId != "" {
+		index, err := r.metadata.forSchema(r.objectschemaId).indexed(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if objectType := index.typesByID[id]; objectType != nil {
+			return objectType, nil
+		}
+	}
+
+	objectType, response, err := r.client.ObjectType.Get(ctx, r.workspaceId, id)
+	if err != nil {
+		if response != nil {
+			return nil, fmt.Errorf("%w: %s", err, response.Bytes.String())
+		}
+		return nil, err
+	}
+	return objectType, nil
+}
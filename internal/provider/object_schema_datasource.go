@@ -114,10 +114,7 @@ func (d *objectSchemaDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// Return an error if the API call fails
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read Assets object schema",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Unable to read Assets object schema", err, schemaResp, nil)...)
 		return
 	}
 
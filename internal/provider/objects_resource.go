@@ -0,0 +1,818 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/ctreminiom/go-atlassian/assets"
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &objectsResource{}
+	_ resource.ResourceWithConfigure      = &objectsResource{}
+	_ resource.ResourceWithModifyPlan     = &objectsResource{}
+	_ resource.ResourceWithValidateConfig = &objectsResource{}
+)
+
+// objectsPageSize is the number of objects requested per AQL page when
+// refreshing, the API may return fewer.
+const objectsPageSize = 500
+
+// maxObjectErrors is the number of failed objects reported one by one, the
+// rest are summarized.
+const maxObjectErrors = 10
+
+// NewObjectsResource is a helper function to simplify the provider implementation.
+func NewObjectsResource() resource.Resource {
+	return &objectsResource{}
+}
+
+// objectsResource manages many objects of one type in a single resource,
+// keyed by the value of one of their attributes. It is refreshed with a few
+// paged AQL searches instead of two requests per object.
+type objectsResource struct {
+	client         *assets.Client
+	workspaceId    string
+	objectschemaId string
+	metadata       *schemaMetadataRegistry
+}
+
+// Metadata returns the resource type name.
+func (r *objectsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "jiraassets_objects"
+}
+
+type objectsResourceModel struct {
+	ObjectSchemaId types.String `tfsdk:"object_schema_id"`
+	Type           types.String `tfsdk:"type"`
+	KeyAttribute   types.String `tfsdk:"key_attribute"`
+	Objects        types.Map    `tfsdk:"objects"`
+	Ids            types.Map    `tfsdk:"ids"`
+	ObjectKeys     types.Map    `tfsdk:"object_keys"`
+	Concurrency    types.Int64  `tfsdk:"concurrency"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// objectsState holds the managed objects by key while they are changed.
+type objectsState struct {
+	attributes map[string]map[string]types.String
+	ids        map[string]string
+	objectKeys map[string]string
+}
+
+func newObjectsState() objectsState {
+	return objectsState{
+		attributes: map[string]map[string]types.String{},
+		ids:        map[string]string{},
+		objectKeys: map[string]string{},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *objectsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many Jira Assets objects of one type, keyed by the value of one of their attributes. Use it instead of jiraassets_object for large sets of objects.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+		Attributes: map[string]schema.Attribute{
+			"object_schema_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the object schema the objects belong to. Defaults to the object_schema_id of the provider.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the object type of the objects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_attribute": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the attribute that identifies an object, e.g. \"Name\". It is set to the key of the object in objects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"objects": schema.MapAttribute{
+				Required:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				Description: "The attributes of every object by the value of its key_attribute. Only the attributes set here are tracked, attributes removed from an object are cleared. Existing objects with a key are taken over instead of creating duplicates.",
+			},
+			"ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of the objects by key.",
+			},
+			"object_keys": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The object keys of the objects by key, e.g. ITSM-1234.",
+			},
+			"concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(4),
+				Description: "The number of objects created, updated or deleted at the same time.",
+			},
+		},
+	}
+}
+
+// schemaMetadata returns the metadata cache of the object schema configured
+// on the resource, falling back to the provider's object schema.
+func (r *objectsResource) schemaMetadata(objectSchemaId types.String) *schemaMetadata {
+	if objectSchemaId.IsNull() || objectSchemaId.IsUnknown() || objectSchemaId.ValueString() == "" {
+		return r.metadata.forSchema(r.objectschemaId)
+	}
+	return r.metadata.forSchema(objectSchemaId.ValueString())
+}
+
+// readObjectsState converts the maps of the model, unknown IDs and object
+// keys of a plan are left empty.
+func readObjectsState(ctx context.Context, model objectsResourceModel) (objectsState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := newObjectsState()
+	if !model.Objects.IsNull() && !model.Objects.IsUnknown() {
+		diags.Append(model.Objects.ElementsAs(ctx, &state.attributes, false)...)
+	}
+	if !model.Ids.IsNull() && !model.Ids.IsUnknown() {
+		diags.Append(model.Ids.ElementsAs(ctx, &state.ids, false)...)
+	}
+	if !model.ObjectKeys.IsNull() && !model.ObjectKeys.IsUnknown() {
+		diags.Append(model.ObjectKeys.ElementsAs(ctx, &state.objectKeys, false)...)
+	}
+	return state, diags
+}
+
+// set stores the objects of state in the model.
+func (state objectsState) set(ctx context.Context, model *objectsResourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	model.Objects, d = types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, state.attributes)
+	diags.Append(d...)
+	model.Ids, d = types.MapValueFrom(ctx, types.StringType, state.ids)
+	diags.Append(d...)
+	model.ObjectKeys, d = types.MapValueFrom(ctx, types.StringType, state.objectKeys)
+	diags.Append(d...)
+	return diags
+}
+
+// searchObjects returns the objects of the type with their attributes by the
+// value of keyAttribute, using paged AQL searches.
+func (r *objectsResource) searchObjects(ctx context.Context, metadata *schemaMetadata, objectType *models.ObjectTypeScheme, keyAttribute string) (map[string][]*models.ObjectScheme, error) {
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aql := "objectSchemaId = " + metadata.schemaId + " AND objectType = " + aqlQuote(objectType.Name)
+	objects := map[string][]*models.ObjectScheme{}
+	for startAt := 0; ; {
+		page, response, err := r.client.Object.Filter(ctx, r.workspaceId, aql, true, startAt, objectsPageSize)
+		if err != nil {
+			if response != nil {
+				return nil, fmt.Errorf("unable to search objects with AQL %q: %w: %s", aql, err, response.Bytes.String())
+			}
+			return nil, fmt.Errorf("unable to search objects with AQL %q: %w", aql, err)
+		}
+		for _, object := range page.Values {
+			key := objectAttributeValues(object.Attributes, index, nil)[keyAttribute]
+			objects[key] = append(objects[key], object)
+		}
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+		startAt += len(page.Values)
+	}
+
+	tflog.Debug(ctx, "Searched objects", map[string]interface{}{
+		"aql":  aql,
+		"keys": len(objects),
+	})
+	return objects, nil
+}
+
+// forEachObject calls fn for every key with at most concurrency calls at the
+// same time. It returns the objects and errors returned by fn by key.
+func forEachObject(ctx context.Context, keys []string, concurrency int64, fn func(ctx context.Context, key string) (*models.ObjectScheme, error)) (map[string]*models.ObjectScheme, map[string]error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	objects := make(map[string]*models.ObjectScheme, len(keys))
+	errs := map[string]error{}
+	slots := make(chan struct{}, max(concurrency, 1))
+	for _, key := range keys {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			object, err := fn(ctx, key)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[key] = err
+				return
+			}
+			objects[key] = object
+		}()
+	}
+	wg.Wait()
+	return objects, errs
+}
+
+// addObjectErrors reports the errors of failed objects, the first few on
+// their key in objects and the rest summarized. Errors returned by Assets
+// are translated with index, so rejected attribute IDs are named.
+func addObjectErrors(ctx context.Context, diags *diag.Diagnostics, summary string, errs map[string]error, index *metadataIndex) {
+	keys := slices.Sorted(maps.Keys(errs))
+	for i, key := range keys {
+		if i == maxObjectErrors {
+			diags.AddError(summary, fmt.Sprintf("%d more objects failed, the first was %q: %s", len(keys)-i, key, errs[key]))
+			return
+		}
+		objectPath := path.Root("objects").AtMapKey(key)
+		var requestErr *objectRequestError
+		if !errors.As(errs[key], &requestErr) {
+			diags.AddAttributeError(objectPath, summary, errs[key].Error())
+			continue
+		}
+		for _, d := range apiErrorDiagnosticsAt(ctx, summary, requestErr.err, requestErr.response, index, func(string) path.Path {
+			return objectPath
+		}, nil) {
+			if _, ok := d.(diag.DiagnosticWithPath); !ok {
+				d = diag.WithPath(objectPath, d)
+			}
+			diags.Append(d)
+		}
+	}
+}
+
+// apply creates, updates and deletes objects so the objects of prior match
+// the planned objects. Objects that fail keep their prior state, so the
+// returned state only records what was applied.
+func (r *objectsResource) apply(ctx context.Context, plan objectsResourceModel, prior objectsState, diags *diag.Diagnostics) objectsState {
+	metadata := r.schemaMetadata(plan.ObjectSchemaId)
+	objectType, err := metadata.objectType(ctx, plan.Type.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Unknown object type",
+			err.Error(),
+		)
+		return prior
+	}
+
+	planned, d := readObjectsState(ctx, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return prior
+	}
+	keyAttribute := plan.KeyAttribute.ValueString()
+
+	// resolve every payload first, so a configuration error changes nothing
+	payloads := map[string]*models.ObjectPayloadScheme{}
+	var adopt bool
+	var unchanged []string
+	for key, elements := range planned.attributes {
+		// the partial update keeps attributes that are not sent, so the ones
+		// removed from an object are cleared explicitly. A changed key read
+		// into the key attribute is not planned and is set back below.
+		cleared := removedAttributes(prior.attributes[key], elements, keyAttribute)
+		if _, ok := prior.ids[key]; ok && len(prior.attributes[key]) == len(elements) && len(changedAttributes(prior.attributes[key], elements)) == 0 {
+			unchanged = append(unchanged, key)
+			continue
+		}
+		elements = maps.Clone(elements)
+		elements[keyAttribute] = types.StringValue(key)
+		attributes, err := attributePayload(ctx, metadata, objectType.Name, elements)
+		if err == nil {
+			var clearing []*models.ObjectPayloadAttributeScheme
+			clearing, err = clearAttributesPayload(ctx, metadata, objectType.Name, cleared)
+			attributes = append(attributes, clearing...)
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("objects").AtMapKey(key),
+				"Error during object attributes setting",
+				err.Error(),
+			)
+			continue
+		}
+		payloads[key] = &models.ObjectPayloadScheme{
+			ObjectTypeID: objectType.Id,
+			Attributes:   attributes,
+		}
+		if _, ok := prior.ids[key]; !ok {
+			adopt = true
+		}
+	}
+	if diags.HasError() {
+		return prior
+	}
+
+	// objects that already have a planned key are updated instead of
+	// creating duplicates
+	existing := map[string][]*models.ObjectScheme{}
+	if adopt {
+		existing, err = r.searchObjects(ctx, metadata, objectType, keyAttribute)
+		if err != nil {
+			diags.AddError(
+				"Unable to search existing objects",
+				err.Error(),
+			)
+			return prior
+		}
+	}
+
+	result := objectsState{
+		attributes: maps.Clone(prior.attributes),
+		ids:        maps.Clone(prior.ids),
+		objectKeys: maps.Clone(prior.objectKeys),
+	}
+	for _, key := range unchanged {
+		result.attributes[key] = planned.attributes[key]
+	}
+
+	var removed []string
+	for key := range prior.ids {
+		if _, ok := planned.attributes[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	tflog.Info(ctx, "Applying objects.", map[string]interface{}{
+		"type":    objectType.Name,
+		"write":   len(payloads),
+		"delete":  len(removed),
+		"planned": len(planned.attributes),
+	})
+
+	_, errs := forEachObject(ctx, removed, plan.Concurrency.ValueInt64(), func(ctx context.Context, key string) (*models.ObjectScheme, error) {
+		return nil, r.deleteObject(ctx, prior.ids[key])
+	})
+	for _, key := range removed {
+		if errs[key] == nil {
+			delete(result.attributes, key)
+			delete(result.ids, key)
+			delete(result.objectKeys, key)
+		}
+	}
+	index, _ := metadata.indexed(ctx)
+	addObjectErrors(ctx, diags, "Error during object deletion", errs, index)
+
+	written, errs := forEachObject(ctx, slices.Collect(maps.Keys(payloads)), plan.Concurrency.ValueInt64(), func(ctx context.Context, key string) (*models.ObjectScheme, error) {
+		id, ok := prior.ids[key]
+		if !ok {
+			switch matches := existing[key]; len(matches) {
+			case 0:
+				object, response, err := r.client.Object.Create(ctx, r.workspaceId, payloads[key])
+				return object, objectResponseError("create", err, response)
+			case 1:
+				id = matches[0].ID
+			default:
+				return nil, fmt.Errorf("%d %s objects have %s %q, keys must be unique", len(matches), objectType.Name, keyAttribute, key)
+			}
+		}
+		object, response, err := r.client.Object.Update(ctx, r.workspaceId, id, payloads[key])
+		return object, objectResponseError("update", err, response)
+	})
+	for key, object := range written {
+		result.attributes[key] = planned.attributes[key]
+		result.ids[key] = object.ID
+		result.objectKeys[key] = object.ObjectKey
+	}
+	addObjectErrors(ctx, diags, "Error during object update", errs, index)
+
+	return result
+}
+
+// removedAttributes returns the sorted names of the attributes in prior
+// that are no longer planned. The key attribute is never removed, it is
+// always set from the key of the object.
+func removedAttributes(prior map[string]types.String, planned map[string]types.String, keyAttribute string) []string {
+	var removed []string
+	for name := range prior {
+		if _, ok := planned[name]; !ok && name != keyAttribute {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// clearAttributesPayload converts the names of attributes into a payload
+// that sets them to an empty value.
+func clearAttributesPayload(ctx context.Context, metadata *schemaMetadata, objectType string, names []string) ([]*models.ObjectPayloadAttributeScheme, error) {
+	var attributes []*models.ObjectPayloadAttributeScheme
+	for _, name := range names {
+		attrSchema, err := metadata.objectAttribute(ctx, objectType, name)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, &models.ObjectPayloadAttributeScheme{
+			ObjectTypeAttributeID: attrSchema.ID,
+			ObjectAttributeValues: []*models.ObjectPayloadAttributeValueScheme{{Value: ""}},
+		})
+	}
+	return attributes, nil
+}
+
+// objectRequestError is a failed object request with the response of
+// Assets, if any.
+type objectRequestError struct {
+	action   string
+	err      error
+	response *models.ResponseScheme
+}
+
+func (e *objectRequestError) Error() string {
+	if e.response != nil {
+		return fmt.Sprintf("unable to %s object at %s: %s: %s", e.action, e.response.Endpoint, e.err, e.response.Bytes.String())
+	}
+	return fmt.Sprintf("unable to %s object: %s", e.action, e.err)
+}
+
+func (e *objectRequestError) Unwrap() error {
+	return e.err
+}
+
+// objectResponseError keeps the response of a failed object request with
+// its error, for addObjectErrors to translate.
+func objectResponseError(action string, err error, response *models.ResponseScheme) error {
+	if err == nil {
+		return nil
+	}
+	return &objectRequestError{action: action, err: err, response: response}
+}
+
+// deleteObject deletes an object, an object that is already gone counts as
+// deleted.
+func (r *objectsResource) deleteObject(ctx context.Context, id string) error {
+	response, err := r.client.Object.Delete(ctx, r.workspaceId, id)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil
+	}
+	return objectResponseError("delete", err, response)
+}
+
+// Create creates the objects and sets the initial Terraform state.
+func (r *objectsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ObjectSchemaId = types.StringValue(r.schemaMetadata(plan.ObjectSchemaId).schemaId)
+	result := r.apply(ctx, plan, newObjectsState(), &resp.Diagnostics)
+	if len(result.ids) == 0 && resp.Diagnostics.HasError() {
+		return
+	}
+
+	// objects that were created are kept in state even if others failed
+	resp.Diagnostics.Append(result.set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with a paged search over the objects of
+// the type.
+func (r *objectsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata := r.schemaMetadata(state.ObjectSchemaId)
+	objectType, err := metadata.objectType(ctx, state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Unknown object type",
+			err.Error(),
+		)
+		return
+	}
+	index, err := metadata.indexed(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to load object schema metadata",
+			err.Error(),
+		)
+		return
+	}
+
+	prior, diags := readObjectsState(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.searchObjects(ctx, metadata, objectType, state.KeyAttribute.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error during object reading",
+			err.Error(),
+		)
+		return
+	}
+
+	byId := map[string]*models.ObjectScheme{}
+	for _, objects := range existing {
+		for _, object := range objects {
+			byId[object.ID] = object
+		}
+	}
+
+	keyAttribute := state.KeyAttribute.ValueString()
+	refreshed := newObjectsState()
+	for key, id := range prior.ids {
+		// the managed object is found by its ID even if its key changed,
+		// only an object that is gone is replaced by one with the key
+		object, ok := byId[id]
+		if !ok && len(existing[key]) > 0 {
+			object = existing[key][0]
+		}
+		if object == nil {
+			tflog.Warn(ctx, "Object not found, removing it from state.", map[string]interface{}{
+				"key": key,
+				"id":  id,
+			})
+			continue
+		}
+
+		values := objectAttributeValues(object.Attributes, index, nil)
+		attributes := make(map[string]types.String, len(prior.attributes[key]))
+		for name := range prior.attributes[key] {
+			if value, ok := values[name]; ok {
+				attributes[name] = types.StringValue(value)
+			}
+		}
+		// a key changed outside of Terraform shows up as a diff on the key
+		// attribute, which the next apply sets back to the key
+		if value := values[keyAttribute]; value != key {
+			attributes[keyAttribute] = types.StringValue(value)
+		}
+		refreshed.attributes[key] = attributes
+		refreshed.ids[key] = object.ID
+		refreshed.objectKeys[key] = object.ObjectKey
+	}
+
+	resp.Diagnostics.Append(refreshed.set(ctx, &state)...)
+	state.ObjectSchemaId = types.StringValue(metadata.schemaId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update creates, updates and deletes the objects that changed.
+func (r *objectsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := readObjectsState(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ObjectSchemaId = types.StringValue(r.schemaMetadata(plan.ObjectSchemaId).schemaId)
+	result := r.apply(ctx, plan, prior, &resp.Diagnostics)
+
+	// record what was applied even if some objects failed
+	resp.Diagnostics.Append(result.set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes all objects and removes the Terraform state on success.
+func (r *objectsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state objectsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := readObjectsState(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, errs := forEachObject(ctx, slices.Collect(maps.Keys(prior.ids)), state.Concurrency.ValueInt64(), func(ctx context.Context, key string) (*models.ObjectScheme, error) {
+		return nil, r.deleteObject(ctx, prior.ids[key])
+	})
+	if len(errs) > 0 {
+		// the metadata is only needed to name the attributes in errors
+		index, _ := r.schemaMetadata(state.ObjectSchemaId).indexed(ctx)
+		addObjectErrors(ctx, &resp.Diagnostics, "Error during object deletion", errs, index)
+	}
+}
+
+// ModifyPlan fills in the provider's object schema and plans the IDs and
+// object keys of the objects: kept objects keep theirs, new ones are unknown.
+func (r *objectsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.metadata == nil {
+		return
+	}
+
+	var configSchemaId, planSchemaId types.String
+	var planObjects types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("object_schema_id"), &configSchemaId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("object_schema_id"), &planSchemaId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("objects"), &planObjects)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configSchemaId.IsNull() && planSchemaId.IsUnknown() && r.objectschemaId != "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("object_schema_id"), types.StringValue(r.objectschemaId))...)
+	}
+
+	if planObjects.IsUnknown() {
+		return
+	}
+	priorIds := map[string]attr.Value{}
+	priorKeys := map[string]attr.Value{}
+	if !req.State.Raw.IsNull() {
+		var ids, objectKeys types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ids"), &ids)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("object_keys"), &objectKeys)...)
+		priorIds = ids.Elements()
+		priorKeys = objectKeys.Elements()
+	}
+
+	ids := make(map[string]attr.Value, len(planObjects.Elements()))
+	objectKeys := make(map[string]attr.Value, len(planObjects.Elements()))
+	for key := range planObjects.Elements() {
+		ids[key] = types.StringUnknown()
+		if id, ok := priorIds[key]; ok {
+			ids[key] = id
+		}
+		objectKeys[key] = types.StringUnknown()
+		if objectKey, ok := priorKeys[key]; ok {
+			objectKeys[key] = objectKey
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ids"), types.MapValueMust(types.StringType, ids))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("object_keys"), types.MapValueMust(types.StringType, objectKeys))...)
+}
+
+// ValidateConfig checks the concurrency, that the key attribute is only set
+// through the keys of objects and, once the provider is configured, that no
+// object sets an attribute that is not editable.
+func (r *objectsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config objectsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Concurrency.IsNull() && !config.Concurrency.IsUnknown() && config.Concurrency.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("concurrency"),
+			"Invalid concurrency",
+			fmt.Sprintf("concurrency must be at least 1, got %d.", config.Concurrency.ValueInt64()),
+		)
+	}
+
+	if config.KeyAttribute.IsUnknown() || config.Objects.IsUnknown() {
+		return
+	}
+	keyAttribute := config.KeyAttribute.ValueString()
+	for key, value := range config.Objects.Elements() {
+		if key == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("objects"),
+				"Invalid object key",
+				"The keys of objects must not be empty.",
+			)
+		}
+		attributes, ok := value.(types.Map)
+		if !ok || attributes.IsUnknown() {
+			continue
+		}
+		if _, ok := attributes.Elements()[keyAttribute]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("objects").AtMapKey(key).AtMapKey(keyAttribute),
+				"Conflicting object attribute",
+				fmt.Sprintf("%s is the key_attribute and is set from the key of the object.", keyAttribute),
+			)
+		}
+	}
+
+	r.validateReadOnlyAttributes(ctx, config, resp)
+}
+
+// validateReadOnlyAttributes checks that neither the key attribute nor the
+// objects set attributes Assets does not allow to edit, like Key.
+func (r *objectsResource) validateReadOnlyAttributes(ctx context.Context, config objectsResourceModel, resp *resource.ValidateConfigResponse) {
+	if r.metadata == nil || config.Type.IsUnknown() || config.ObjectSchemaId.IsUnknown() {
+		return
+	}
+	index, err := r.schemaMetadata(config.ObjectSchemaId).indexed(ctx)
+	if err != nil {
+		// planning loads the metadata again and reports the error
+		return
+	}
+	readOnly := func(name string) bool {
+		return index.readOnlyAttributesByName[objectAttributeKey{objectType: config.Type.ValueString(), name: name}] != nil
+	}
+
+	if readOnly(config.KeyAttribute.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_attribute"),
+			"Read only object attribute",
+			fmt.Sprintf("Attribute %q of object type %q is not editable in Assets and cannot be the key_attribute.", config.KeyAttribute.ValueString(), config.Type.ValueString()),
+		)
+	}
+	for _, key := range slices.Sorted(maps.Keys(config.Objects.Elements())) {
+		attributes, ok := config.Objects.Elements()[key].(types.Map)
+		if !ok || attributes.IsUnknown() {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(attributes.Elements())) {
+			if readOnly(name) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("objects").AtMapKey(key).AtMapKey(name),
+					"Read only object attribute",
+					fmt.Sprintf("Attribute %q of object type %q is not editable in Assets and cannot be set.", name, config.Type.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// Configure configures the resource with the given configuration.
+func (r *objectsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(JiraAssetsProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected JiraAssetsProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerClient.client
+	r.workspaceId = providerClient.workspaceId
+	r.objectschemaId = providerClient.objectschemaId
+	r.metadata = providerClient.metadata
+}
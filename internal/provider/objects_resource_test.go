@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testObjectsModel(t *testing.T, objects map[string]map[string]string) objectsResourceModel {
+	t.Helper()
+
+	elements := make(map[string]attr.Value, len(objects))
+	for key, attributes := range objects {
+		elements[key] = types.MapValueMust(types.StringType, testStringValues(attributes))
+	}
+	return objectsResourceModel{
+		ObjectSchemaId: types.StringUnknown(),
+		Type:           types.StringValue("Host"),
+		KeyAttribute:   types.StringValue("Name"),
+		Objects:        types.MapValueMust(types.MapType{ElemType: types.StringType}, elements),
+		Ids:            types.MapUnknown(types.StringType),
+		ObjectKeys:     types.MapUnknown(types.StringType),
+		Concurrency:    types.Int64Value(2),
+		Timeouts:       nullTimeouts(),
+	}
+}
+
+func TestObjectsResourceLifecycle(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	single := testResource(t, NewObjectResource().(*objectResource), client)
+	r := testResource(t, NewObjectsResource().(*objectsResource), client)
+
+	// an existing object with a planned key is taken over
+	adopted, diags := testResourceCreate(t, single, testObjectModel(t, "Host", map[string]string{"Name": "web02", "Hostname": "old.example.com"}))
+	if diags.HasError() {
+		t.Fatalf("Create single object: %v", diags)
+	}
+
+	created, diags := testResourceCreate(t, r, testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Hostname": "web01.example.com", "Status": "Active"},
+		"web02": {"Hostname": "web02.example.com"},
+		"web03": {"Status": "Retired"},
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	ids := testMapStrings(t, created.Ids)
+	if len(ids) != 3 || ids["web02"] != adopted.Id.ValueString() {
+		t.Fatalf("ids = %v, want web02 to be %s", ids, adopted.Id.ValueString())
+	}
+	if len(fake.objects) != 3 {
+		t.Errorf("fake holds %d objects, want 3", len(fake.objects))
+	}
+	if values := fake.attributeValues(ids["web01"], "Name"); len(values) != 1 || values[0].Value != "web01" {
+		t.Errorf("key attribute of web01 = %+v", values)
+	}
+	if keys := testMapStrings(t, created.ObjectKeys); keys["web03"] != fake.objects[ids["web03"]].ObjectKey {
+		t.Errorf("object_keys = %v", keys)
+	}
+
+	// drift is found with AQL searches alone
+	fake.attributeValues(ids["web01"], "Hostname")[0].Value = "drifted.example.com"
+	getsBefore := fake.requestCount("GET", "/object/{id}") + fake.requestCount("GET", "/object/{id}/attributes")
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if n := fake.requestCount("GET", "/object/{id}") + fake.requestCount("GET", "/object/{id}/attributes") - getsBefore; n != 0 {
+		t.Errorf("Read made %d requests per object", n)
+	}
+	var objects map[string]map[string]string
+	read.Objects.ElementsAs(context.Background(), &objects, false)
+	if objects["web01"]["Hostname"] != "drifted.example.com" || objects["web03"]["Status"] != "Retired" || len(objects["web02"]) != 1 {
+		t.Errorf("objects after Read = %v", objects)
+	}
+
+	// only changed, added and removed objects are written
+	plan := testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Hostname": "web01.example.com", "Status": "Active"},
+		"web02": {"Hostname": "web02.example.com"},
+		"web04": {"Status": "Active"},
+	})
+	plan.ObjectSchemaId = read.ObjectSchemaId
+	putsBefore := fake.requestCount("PUT", "/object/{id}")
+	updated, diags := testResourceUpdate(t, r, read, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if n := fake.requestCount("PUT", "/object/{id}") - putsBefore; n != 1 {
+		t.Errorf("Update sent %d object updates, want 1", n)
+	}
+	if _, ok := fake.objects[ids["web03"]]; ok {
+		t.Error("removed object web03 was not deleted")
+	}
+	updatedIds := testMapStrings(t, updated.Ids)
+	if len(updatedIds) != 3 || updatedIds["web01"] != ids["web01"] || updatedIds["web04"] == "" {
+		t.Errorf("ids after Update = %v", updatedIds)
+	}
+
+	if diags := testResourceDelete(t, r, updated); diags.HasError() {
+		t.Fatalf("Delete: %v", diags)
+	}
+	if len(fake.objects) != 0 {
+		t.Errorf("fake holds %d objects after Delete", len(fake.objects))
+	}
+}
+
+func TestObjectsResourceRemovedAttributes(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectsResource().(*objectsResource), testProviderClient(t, fake))
+
+	created, diags := testResourceCreate(t, r, testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Hostname": "web01.example.com", "Status": "Active"},
+	}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	id := testMapStrings(t, created.Ids)["web01"]
+
+	// an object whose only change is a removed attribute is written
+	plan := testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Status": "Active"},
+	})
+	plan.ObjectSchemaId = created.ObjectSchemaId
+	putsBefore := fake.requestCount("PUT", "/object/{id}")
+	updated, diags := testResourceUpdate(t, r, created, plan)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if n := fake.requestCount("PUT", "/object/{id}") - putsBefore; n != 1 {
+		t.Errorf("Update sent %d object updates, want 1", n)
+	}
+	if values := fake.attributeValues(id, "Hostname"); len(values) != 1 || values[0].Value != "" {
+		t.Errorf("removed attribute Hostname = %+v, want it cleared", values)
+	}
+	if values := fake.attributeValues(id, "Status"); len(values) != 1 || values[0].DisplayValue != "Active" {
+		t.Errorf("kept attribute Status = %+v", values)
+	}
+
+	read, _, diags := testResourceRead(t, r, updated)
+	if diags.HasError() {
+		t.Fatalf("Read: %v", diags)
+	}
+	var objects map[string]map[string]string
+	read.Objects.ElementsAs(context.Background(), &objects, false)
+	testAssertAttributes(t, objects["web01"], map[string]string{"Status": "Active"})
+}
+
+func TestObjectsResourceChangedKey(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectsResource().(*objectsResource), testProviderClient(t, fake))
+
+	config := testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Hostname": "web01.example.com"},
+		"web02": {"Hostname": "web02.example.com"},
+	})
+	created, diags := testResourceCreate(t, r, config)
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+	ids := testMapStrings(t, created.Ids)
+
+	// the object is renamed outside of Terraform
+	fake.attributeValues(ids["web01"], "Name")[0].Value = "web09"
+	read, removed, diags := testResourceRead(t, r, created)
+	if diags.HasError() || removed {
+		t.Fatalf("Read: removed=%t %v", removed, diags)
+	}
+	if readIds := testMapStrings(t, read.Ids); readIds["web01"] != ids["web01"] {
+		t.Fatalf("ids after Read = %v, want web01 to stay %s", readIds, ids["web01"])
+	}
+	var objects map[string]map[string]string
+	read.Objects.ElementsAs(context.Background(), &objects, false)
+	testAssertAttributes(t, objects["web01"], map[string]string{"Hostname": "web01.example.com", "Name": "web09"})
+	testAssertAttributes(t, objects["web02"], map[string]string{"Hostname": "web02.example.com"})
+
+	// applying the configuration sets the key again
+	config.ObjectSchemaId = read.ObjectSchemaId
+	updated, diags := testResourceUpdate(t, r, read, config)
+	if diags.HasError() {
+		t.Fatalf("Update: %v", diags)
+	}
+	if values := fake.attributeValues(ids["web01"], "Name"); len(values) != 1 || values[0].Value != "web01" {
+		t.Errorf("key attribute after Update = %+v, want web01", values)
+	}
+	if len(fake.objects) != 2 || testMapStrings(t, updated.Ids)["web01"] != ids["web01"] {
+		t.Errorf("Update left %d objects and ids %v", len(fake.objects), testMapStrings(t, updated.Ids))
+	}
+}
+
+func TestObjectsResourcePartialFailure(t *testing.T) {
+	fake := newFakeAssets(t)
+	fake.rejected = map[string]string{"11": "Hostname is read only"}
+	r := testResource(t, NewObjectsResource().(*objectsResource), testProviderClient(t, fake))
+
+	plan := testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Hostname": "web01.example.com"},
+		"web02": {"Status": "Active"},
+	})
+	// the state is checked despite the error, so Create is called directly
+	s := testResourceSchema(t, r).Schema
+	raw := testResourceValue(t, r, &plan)
+	resp := &fwresource.CreateResponse{State: testNullState(t, r)}
+	r.Create(context.Background(), fwresource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+	}, resp)
+	diags := resp.Diagnostics
+	if !diags.HasError() {
+		t.Fatal("Create with a rejected attribute returned no error")
+	}
+	if len(diags.Errors()) != 1 || !diags.Errors()[0].(diag.DiagnosticWithPath).Path().Equal(path.Root("objects").AtMapKey("web01")) {
+		t.Errorf("Create errors = %v, want one on objects[\"web01\"]", diags)
+	} else if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `attribute "Hostname": Hostname is read only`) {
+		t.Errorf("Create error detail = %q, want the rejected attribute named", detail)
+	}
+
+	var created objectsResourceModel
+	if diags := resp.State.Get(context.Background(), &created); diags.HasError() {
+		t.Fatalf("state after partial Create: %v", diags)
+	}
+	if ids := testMapStrings(t, created.Ids); len(ids) != 1 || ids["web02"] == "" {
+		t.Errorf("ids after partial Create = %v, want only web02", ids)
+	}
+}
+
+func TestObjectsResourceValidation(t *testing.T) {
+	r := NewObjectsResource().(*objectsResource)
+
+	config := testObjectsModel(t, map[string]map[string]string{"web01": {"Status": "Active"}})
+	config.ObjectSchemaId = types.StringNull()
+	config.Ids = types.MapNull(types.StringType)
+	config.ObjectKeys = types.MapNull(types.StringType)
+	if diags := testResourceValidateConfig(t, r, config); diags.HasError() {
+		t.Errorf("ValidateConfig: %v", diags)
+	}
+
+	config.Concurrency = types.Int64Value(0)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("concurrency 0 returned no error")
+	}
+
+	config = testObjectsModel(t, map[string]map[string]string{"web01": {"Name": "web01"}})
+	config.ObjectSchemaId = types.StringNull()
+	config.Ids = types.MapNull(types.StringType)
+	config.ObjectKeys = types.MapNull(types.StringType)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("key_attribute in the attributes of an object returned no error")
+	}
+
+	// read only attributes are rejected once the provider is configured
+	r = testResource(t, NewObjectsResource().(*objectsResource), testProviderClient(t, newFakeAssets(t)))
+	config = testObjectsModel(t, map[string]map[string]string{"web01": {"Status": "Active", "Created": "2024-01-01"}})
+	config.ObjectSchemaId = types.StringNull()
+	config.Ids = types.MapNull(types.StringType)
+	config.ObjectKeys = types.MapNull(types.StringType)
+	diags := testResourceValidateConfig(t, r, config)
+	if len(diags.Errors()) != 1 || !diags.Errors()[0].(diag.DiagnosticWithPath).Path().Equal(path.Root("objects").AtMapKey("web01").AtMapKey("Created")) {
+		t.Errorf("ValidateConfig errors = %v, want one on objects[\"web01\"][\"Created\"]", diags)
+	}
+
+	config = testObjectsModel(t, map[string]map[string]string{"ITSM-1": {"Status": "Active"}})
+	config.KeyAttribute = types.StringValue("Key")
+	config.ObjectSchemaId = types.StringNull()
+	config.Ids = types.MapNull(types.StringType)
+	config.ObjectKeys = types.MapNull(types.StringType)
+	if diags := testResourceValidateConfig(t, r, config); !diags.HasError() {
+		t.Error("read only key_attribute returned no error")
+	}
+}
+
+func TestObjectsResourceModifyPlan(t *testing.T) {
+	fake := newFakeAssets(t)
+	r := testResource(t, NewObjectsResource().(*objectsResource), testProviderClient(t, fake))
+
+	created, diags := testResourceCreate(t, r, testObjectsModel(t, map[string]map[string]string{"web01": {"Status": "Active"}}))
+	if diags.HasError() {
+		t.Fatalf("Create: %v", diags)
+	}
+
+	config := testObjectsModel(t, map[string]map[string]string{
+		"web01": {"Status": "Retired"},
+		"web02": {"Status": "Active"},
+	})
+	plan, diags := testResourceModifyPlan(t, r, &created, config)
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan: %v", diags)
+	}
+	ids := plan.Ids.Elements()
+	if len(ids) != 2 || !ids["web01"].Equal(created.Ids.Elements()["web01"]) || !ids["web02"].IsUnknown() {
+		t.Errorf("planned ids = %s", plan.Ids)
+	}
+}
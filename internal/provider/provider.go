@@ -5,11 +5,14 @@ package provider
 
 import (
 	"context"
-	"log"
-	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,11 +21,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/ctreminiom/go-atlassian/assets"
-	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &JiraAssetsProvider{}
+var (
+	_ provider.Provider                       = &JiraAssetsProvider{}
+	_ provider.ProviderWithListResources      = &JiraAssetsProvider{}
+	_ provider.ProviderWithEphemeralResources = &JiraAssetsProvider{}
+	_ provider.ProviderWithFunctions          = &JiraAssetsProvider{}
+)
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
@@ -43,79 +50,27 @@ type JiraAssetsProvider struct {
 
 // JiraAssetsProviderModel describes the provider data model.
 type JiraAssetsProviderModel struct {
-	WorkspaceId    types.String `tfsdk:"workspace_id"`
-	User           types.String `tfsdk:"user"`
-	Password       types.String `tfsdk:"password"`
-	ObjectSchemaId types.String `tfsdk:"object_schema_id"`
-	IgnoreKeys     []string     `tfsdk:"ignore_keys"`
+	ApiUrl               types.String `tfsdk:"api_url"`
+	WorkspaceId          types.String `tfsdk:"workspace_id"`
+	User                 types.String `tfsdk:"user"`
+	Password             types.String `tfsdk:"password"`
+	ObjectSchemaId       types.String `tfsdk:"object_schema_id"`
+	IgnoreKeys           []string     `tfsdk:"ignore_keys"`
+	ClosedTicketStatuses []string     `tfsdk:"closed_ticket_statuses"`
+	MetadataCacheDir     types.String `tfsdk:"metadata_cache_dir"`
+	MetadataCacheTTL     types.String `tfsdk:"metadata_cache_ttl"`
 }
 
 // JiraAssetsProviderClient describes client and worksapceId.
 type JiraAssetsProviderClient struct {
-	client                 *assets.Client
-	workspaceId            string
-	objectschemaId         string
-	ignoreKeys             []string
-	objectSchemaTypes      []*models.ObjectTypeScheme
-	objectSchemaAttributes []*models.ObjectTypeAttributeScheme
-	configStatusType       *[]StatusTypeMetadata
-}
-
-type StatusTypeMetadata struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	Category       int    `json:"category"`
-	ObjectSchemaId string `json:"objectSchemaId"`
-}
-
-func getConfigStatusType(asset *assets.Client, workSpaceID string, schemaId string) *[]StatusTypeMetadata {
-	apiEndpoint := "/jsm/assets/workspace/" + workSpaceID + "/v1/config/statustype?objectSchemaId=" + schemaId
-
-	request, err := asset.NewRequest(context.Background(), http.MethodGet, apiEndpoint, "", nil)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	customResponseStruct := new([]StatusTypeMetadata)
-	response, err := asset.Call(request, &customResponseStruct)
-	if err != nil {
-		log.Fatal(err)
-	}
-	if response.StatusCode == http.StatusOK {
-		return customResponseStruct
-	} else {
-		return nil
-	}
-}
-
-func getObjectSchemaAttributes(asset *assets.Client, workSpaceID string, schemaID string) []*models.ObjectTypeAttributeScheme {
-	options := &models.ObjectSchemaAttributesParamsScheme{
-		OnlyValueEditable: true,
-		Extended:          true,
-		Query:             "",
-	}
-	attributes, response, err := asset.ObjectSchema.Attributes(context.Background(), workSpaceID, schemaID, options)
-	if err != nil {
-		if response != nil {
-			log.Println(response.Bytes.String())
-			log.Println("Endpoint:", response.Endpoint)
-		}
-		log.Fatal(err)
-	}
-	return attributes
-}
-
-func getObjectSchemaObjectTypes(asset *assets.Client, workSpaceID string, objsectSchemaID string) []*models.ObjectTypeScheme {
-	schema, response, err := asset.ObjectSchema.ObjectTypes(context.Background(), workSpaceID, objsectSchemaID, true)
-	if err != nil {
-		if response != nil {
-			log.Println(response.Bytes.String())
-			log.Println("Endpoint:", response.Endpoint)
-		}
-		log.Fatal(err)
-	}
-	return schema
+	client         *assets.Client
+	workspaceId    string
+	objectschemaId string
+	ignoreKeys     []string
+	// closedTicketStatuses are the status names of connected tickets that
+	// are done, see ticketIsOpen
+	closedTicketStatuses []string
+	metadata             *schemaMetadataRegistry
 }
 
 func (p *JiraAssetsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -127,6 +82,10 @@ func (p *JiraAssetsProvider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A Terraform provider for Jira Assets.",
 		Attributes: map[string]schema.Attribute{
+			"api_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Assets API. Defaults to `https://api.atlassian.com/`, override it to use a proxy or a test server.",
+				Optional:            true,
+			},
 			"workspace_id": schema.StringAttribute{
 				MarkdownDescription: "Workspace Id of the Assets instance.",
 				Optional:            true,
@@ -141,7 +100,7 @@ func (p *JiraAssetsProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Sensitive:           true,
 			},
 			"object_schema_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the object schema to use.",
+				MarkdownDescription: "ID of the object schema used by resources that do not set their own `object_schema_id`.",
 				Optional:            true,
 			},
 			"ignore_keys": schema.ListAttribute{
@@ -149,6 +108,19 @@ func (p *JiraAssetsProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
+			"closed_ticket_statuses": schema.ListAttribute{
+				MarkdownDescription: "Names of the Jira statuses in which connected tickets count as done for `prevent_destroy_if_open_tickets` and the `open` flag of `jiraassets_object_connected_tickets`, compared case-insensitively. Connected tickets carry neither the status category nor the resolution, so by default tickets with a green status, the color of the Done category, count as done.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"metadata_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory to cache object schema metadata in between runs. Entries are keyed by workspace and object schema and invalidated when the schema is updated. Caching is disabled when unset.",
+				Optional:            true,
+			},
+			"metadata_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "Maximum age of cached object schema metadata as a positive Go duration, e.g. `30m`. Defaults to `1h`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -208,10 +180,17 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 
 	// Default values to environment variables, but override with Terraform configuration value if set.
 
+	apiUrl := os.Getenv("JIRAASSETS_API_URL")
 	workspaceId := os.Getenv("JIRAASSETS_WORKSPACE_ID")
 	user := os.Getenv("JIRAASSETS_USER")
 	password := os.Getenv("JIRAASSETS_PASSWORD")
 	objectschemaId := os.Getenv("JIRAASSETS_OBJECTSCHEMA_ID")
+	metadataCacheDir := os.Getenv("JIRAASSETS_METADATA_CACHE_DIR")
+	metadataCacheTTL := os.Getenv("JIRAASSETS_METADATA_CACHE_TTL")
+
+	if !config.ApiUrl.IsNull() {
+		apiUrl = config.ApiUrl.ValueString()
+	}
 
 	if !config.WorkspaceId.IsNull() {
 		workspaceId = config.WorkspaceId.ValueString()
@@ -229,6 +208,14 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 		objectschemaId = config.ObjectSchemaId.ValueString()
 	}
 
+	if !config.MetadataCacheDir.IsNull() {
+		metadataCacheDir = config.MetadataCacheDir.ValueString()
+	}
+
+	if !config.MetadataCacheTTL.IsNull() {
+		metadataCacheTTL = config.MetadataCacheTTL.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return errors with provider-specific guidance.
 
 	if workspaceId == "" {
@@ -261,6 +248,26 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	var cacheTTL time.Duration
+	if metadataCacheTTL != "" {
+		var err error
+		cacheTTL, err = time.ParseDuration(metadataCacheTTL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metadata_cache_ttl"),
+				"Invalid Metadata Cache TTL",
+				"The metadata cache TTL must be a duration such as \"30m\" or \"12h\". Error: "+err.Error(),
+			)
+		} else if cacheTTL <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metadata_cache_ttl"),
+				"Invalid Metadata Cache TTL",
+				"The metadata cache TTL must be a positive duration such as \"30m\" or \"12h\", got \""+metadataCacheTTL+"\". "+
+					"Unset metadata_cache_dir to disable caching.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -274,36 +281,42 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 	tflog.Debug(ctx, "Creating HashiCups client")
 
 	// create the Jira Assets client
-	client, err := assets.New(nil, "")
+	// API endpoints are resolved relative to the site, which only keeps a
+	// path prefix when the URL ends with a slash
+	if apiUrl != "" && !strings.HasSuffix(apiUrl, "/") {
+		apiUrl += "/"
+	}
+	client, err := assets.New(nil, apiUrl)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Assets client",
 			"An unexpected error occurred when creating the Assets API client. Error: "+err.Error(),
 		)
+		return
 	}
 
 	// add authentication headers to the client, workspaceId is added to each request
 	client.Auth.SetBasicAuth(user, password)
 
-	// build shcme and attribute mappings
-	objectSchemaTypes := getObjectSchemaObjectTypes(client, workspaceId, objectschemaId)
-	objectSchemaAttributes := getObjectSchemaAttributes(client, workspaceId, objectschemaId)
-	statusType := getConfigStatusType(client, workspaceId, objectschemaId)
+	// schema and attribute mappings are fetched on first use, so plans that
+	// never touch an object do not pay for them
+	metadata := newSchemaMetadataRegistry(client, workspaceId, newMetadataDiskCache(metadataCacheDir, cacheTTL))
 
 	// add workspaceId to response to be used by resources and data sources
 	providerClient := JiraAssetsProviderClient{
-		client:                 client,
-		workspaceId:            workspaceId,
-		objectschemaId:         objectschemaId,
-		ignoreKeys:             config.IgnoreKeys,
-		objectSchemaTypes:      objectSchemaTypes,
-		objectSchemaAttributes: objectSchemaAttributes,
-		configStatusType:       statusType,
+		client:               client,
+		workspaceId:          workspaceId,
+		objectschemaId:       objectschemaId,
+		ignoreKeys:           config.IgnoreKeys,
+		closedTicketStatuses: config.ClosedTicketStatuses,
+		metadata:             metadata,
 	}
 
 	resp.DataSourceData = providerClient
 	resp.ResourceData = providerClient
+	resp.ListResourceData = providerClient
+	resp.EphemeralResourceData = providerClient
 
 	tflog.Info(ctx, "Configured Jira Assets client", map[string]any{"success": true})
 }
@@ -311,11 +324,38 @@ func (p *JiraAssetsProvider) Configure(ctx context.Context, req provider.Configu
 func (p *JiraAssetsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewObjectResource,
+		NewObjectsResource,
+		NewObjectAttachmentResource,
+		NewObjectCommentResource,
 	}
 }
 
 func (p *JiraAssetsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewObjectSchemaDataSource,
+		NewObjectAttachmentsDataSource,
+		NewObjectHistoryDataSource,
+		NewObjectConnectedTicketsDataSource,
+	}
+}
+
+func (p *JiraAssetsProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewObjectEphemeralResource,
+	}
+}
+
+func (p *JiraAssetsProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewAqlQuoteFunction,
+		NewAqlEqFunction,
+		NewAqlInFunction,
+		NewParseObjectKeyFunction,
+	}
+}
+
+func (p *JiraAssetsProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewObjectListResource,
 	}
 }
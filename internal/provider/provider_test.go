@@ -4,8 +4,20 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,3 +27,362 @@ import (
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"jiraassets": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+// testAccProviderConfig returns a provider block pointing at the fake API.
+func testAccProviderConfig(fake *fakeAssets) string {
+	return fmt.Sprintf(`
+provider "jiraassets" {
+  api_url          = %q
+  workspace_id     = %q
+  user             = "test@example.com"
+  password         = "test-token"
+  object_schema_id = "1"
+}
+`, fake.URL, fakeWorkspaceId)
+}
+
+// testProviderConfig returns a provider configuration for the fake API,
+// callers adjust it before passing it to testConfigureProvider.
+func testProviderConfig(fake *fakeAssets) JiraAssetsProviderModel {
+	return JiraAssetsProviderModel{
+		ApiUrl:         types.StringValue(fake.URL),
+		WorkspaceId:    types.StringValue(fakeWorkspaceId),
+		User:           types.StringValue("test@example.com"),
+		Password:       types.StringValue("test-token"),
+		ObjectSchemaId: types.StringValue("1"),
+	}
+}
+
+// testConfigureProvider runs the provider's Configure with the given
+// configuration and returns the data handed to resources and data sources.
+func testConfigureProvider(t *testing.T, config JiraAssetsProviderModel) (JiraAssetsProviderClient, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	// keep the developer's environment out of the configuration
+	for _, env := range []string{"JIRAASSETS_API_URL", "JIRAASSETS_WORKSPACE_ID", "JIRAASSETS_USER", "JIRAASSETS_PASSWORD", "JIRAASSETS_OBJECTSCHEMA_ID", "JIRAASSETS_METADATA_CACHE_DIR", "JIRAASSETS_METADATA_CACHE_TTL"} {
+		t.Setenv(env, "")
+	}
+
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	raw := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("building provider config: %v", diags)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		return JiraAssetsProviderClient{}, resp.Diagnostics
+	}
+	client, ok := resp.ResourceData.(JiraAssetsProviderClient)
+	if !ok {
+		t.Fatalf("unexpected resource data %T", resp.ResourceData)
+	}
+	// lookup misses reload right away instead of waiting on the rate limit
+	client.metadata.refreshInterval = 0
+	return client, resp.Diagnostics
+}
+
+// testProviderClient configures the provider against the fake API.
+func testProviderClient(t *testing.T, fake *fakeAssets) JiraAssetsProviderClient {
+	t.Helper()
+
+	client, diags := testConfigureProvider(t, testProviderConfig(fake))
+	if diags.HasError() {
+		t.Fatalf("configuring provider: %v", diags)
+	}
+	return client
+}
+
+// testResource returns a resource configured with the given provider data.
+func testResource[R resource.ResourceWithConfigure](t *testing.T, r R, client JiraAssetsProviderClient) R {
+	t.Helper()
+
+	resp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configuring resource: %v", resp.Diagnostics)
+	}
+	return r
+}
+
+func testResourceSchema(t *testing.T, r resource.Resource) resource.SchemaResponse {
+	t.Helper()
+
+	resp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("resource schema: %v", resp.Diagnostics)
+	}
+	return resp
+}
+
+// testResourceValue converts a resource model into a raw value of the
+// resource's schema.
+func testResourceValue(t *testing.T, r resource.Resource, model any) tftypes.Value {
+	t.Helper()
+
+	state := tfsdk.State{Schema: testResourceSchema(t, r).Schema}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("building resource value: %v", diags)
+	}
+	return state.Raw
+}
+
+func testNullState(t *testing.T, r resource.Resource) tfsdk.State {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+}
+
+// testResourceCreate runs Create with the model as configuration and plan
+// and returns the resulting state.
+func testResourceCreate[M any](t *testing.T, r resource.Resource, plan M) (M, diag.Diagnostics) {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	raw := testResourceValue(t, r, &plan)
+	resp := &resource.CreateResponse{State: testNullState(t, r)}
+	r.Create(context.Background(), resource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+	}, resp)
+
+	var state M
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	}
+	return state, resp.Diagnostics
+}
+
+// testResourceRead runs Read on the state and returns the refreshed state,
+// removed reports whether the resource was removed from state.
+func testResourceRead[M any](t *testing.T, r resource.Resource, state M) (refreshed M, removed bool, diags diag.Diagnostics) {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	raw := testResourceValue(t, r, &state)
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	r.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: raw}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		return refreshed, false, resp.Diagnostics
+	}
+	if resp.State.Raw.IsNull() {
+		return refreshed, true, resp.Diagnostics
+	}
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &refreshed)...)
+	return refreshed, false, resp.Diagnostics
+}
+
+// testResourceUpdate runs Update from the prior state to the plan, which is
+// also used as configuration.
+func testResourceUpdate[M any](t *testing.T, r resource.Resource, prior M, plan M) (M, diag.Diagnostics) {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	priorRaw := testResourceValue(t, r, &prior)
+	planRaw := testResourceValue(t, r, &plan)
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: priorRaw}}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: planRaw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: planRaw},
+		State:  tfsdk.State{Schema: s, Raw: priorRaw},
+	}, resp)
+
+	var state M
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	}
+	return state, resp.Diagnostics
+}
+
+// testResourceValidateConfig runs ValidateConfig on config.
+func testResourceValidateConfig[M any](t *testing.T, r resource.ResourceWithValidateConfig, config M) diag.Diagnostics {
+	t.Helper()
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: testResourceSchema(t, r).Schema, Raw: testResourceValue(t, r, &config)},
+	}, resp)
+	return resp.Diagnostics
+}
+
+// testResourceModifyPlan plans config on top of prior, a nil prior plans a
+// create. Config values double as the proposed plan.
+func testResourceModifyPlan[M any](t *testing.T, r resource.ResourceWithModifyPlan, prior *M, config M) (M, diag.Diagnostics) {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	configRaw := testResourceValue(t, r, &config)
+	state := testNullState(t, r)
+	if prior != nil {
+		state.Raw = testResourceValue(t, r, prior)
+	}
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: configRaw}}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: configRaw},
+		Plan:   tfsdk.Plan{Schema: s, Raw: configRaw},
+		State:  state,
+	}, resp)
+
+	var plan M
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &plan)...)
+	}
+	return plan, resp.Diagnostics
+}
+
+// testResourceDelete runs Delete on the state.
+func testResourceDelete[M any](t *testing.T, r resource.Resource, state M) diag.Diagnostics {
+	t.Helper()
+
+	s := testResourceSchema(t, r).Schema
+	raw := testResourceValue(t, r, &state)
+	resp := &resource.DeleteResponse{State: tfsdk.State{Schema: s, Raw: raw}}
+	r.Delete(context.Background(), resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: raw}}, resp)
+	return resp.Diagnostics
+}
+
+// testResourceImport runs ImportState with the given import ID and returns
+// the state handed to the following Read.
+func testResourceImport[M any](t *testing.T, r resource.ResourceWithImportState, id string) (M, diag.Diagnostics) {
+	t.Helper()
+
+	resp := &resource.ImportStateResponse{State: testNullState(t, r)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+
+	var state M
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	}
+	return state, resp.Diagnostics
+}
+
+func TestProviderConfigureIsLazy(t *testing.T) {
+	fake := newFakeAssets(t)
+
+	config := testProviderConfig(fake)
+	config.ObjectSchemaId = types.StringNull()
+	client, diags := testConfigureProvider(t, config)
+	if diags.HasError() {
+		t.Fatalf("Configure without object_schema_id: %v", diags)
+	}
+
+	for _, route := range []string{"/objecttypes", "/attributes", "/config/statustype"} {
+		if n := fake.requestCount("GET", route); n != 0 {
+			t.Errorf("Configure called GET %s %d times, want 0", route, n)
+		}
+	}
+
+	if _, err := client.metadata.forSchema("").indexed(context.Background()); err == nil {
+		t.Error("loading metadata without an object schema returned no error")
+	}
+}
+
+func TestProviderConfigureInvalidCacheTTL(t *testing.T) {
+	fake := newFakeAssets(t)
+
+	config := testProviderConfig(fake)
+	config.MetadataCacheDir = types.StringValue(t.TempDir())
+	config.MetadataCacheTTL = types.StringValue("a while")
+	if _, diags := testConfigureProvider(t, config); !diags.HasError() {
+		t.Error("Configure with an invalid metadata_cache_ttl returned no error")
+	}
+
+	for _, ttl := range []string{"0s", "-5m"} {
+		config.MetadataCacheTTL = types.StringValue(ttl)
+		_, diags := testConfigureProvider(t, config)
+		if !diags.HasError() {
+			t.Errorf("Configure with metadata_cache_ttl %q returned no error", ttl)
+			continue
+		}
+		if withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("metadata_cache_ttl")) {
+			t.Errorf("metadata_cache_ttl %q: error %v is not reported on metadata_cache_ttl", ttl, diags.Errors()[0])
+		}
+	}
+}
+
+func TestProviderMetadataDiskCache(t *testing.T) {
+	fake := newFakeAssets(t)
+	dir := t.TempDir()
+
+	for run := 0; run < 2; run++ {
+		config := testProviderConfig(fake)
+		config.MetadataCacheDir = types.StringValue(dir)
+		client, diags := testConfigureProvider(t, config)
+		if diags.HasError() {
+			t.Fatalf("Configure: %v", diags)
+		}
+		if _, err := client.metadata.forSchema("1").objectType(context.Background(), "Host"); err != nil {
+			t.Fatalf("run %d: %s", run, err)
+		}
+	}
+
+	if n := fake.requestCount("GET", "/objectschema/{id}/attributes"); n != 1 {
+		t.Errorf("attributes were fetched %d times over two runs, want 1", n)
+	}
+}
+
+func TestSchemaMetadataRefreshOnMiss(t *testing.T) {
+	fake := newFakeAssets(t)
+	client := testProviderClient(t, fake)
+	ctx := context.Background()
+
+	metadata := client.metadata.forSchema("1")
+	if _, err := metadata.objectAttribute(ctx, "Host", "Hostname"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.mu.Lock()
+	fake.addAttribute(fake.objectType("1"), "14", "Rack", 0, false)
+	fake.mu.Unlock()
+
+	attr, err := metadata.objectAttribute(ctx, "Host", "Rack")
+	if err != nil {
+		t.Fatalf("attribute created after the first load: %s", err)
+	}
+	if attr.ID != "14" {
+		t.Errorf("got attribute %s, want 14", attr.ID)
+	}
+	if _, err := metadata.objectAttribute(ctx, "Host", "Missing"); err == nil {
+		t.Error("unknown attribute returned no error")
+	}
+	if n := fake.requestCount("GET", "/objectschema/{id}/attributes"); n != 3 {
+		t.Errorf("attributes were fetched %d times, want 3", n)
+	}
+}
+
+// testDataSourceRead runs Read with the model as configuration and returns
+// the resulting state.
+func testDataSourceRead[M any](t *testing.T, d datasource.DataSourceWithConfigure, client JiraAssetsProviderClient, config M) (M, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	configureResp := &datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configuring data source: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	raw := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("building data source config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw}}, resp)
+
+	var state M
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+	return state, resp.Diagnostics
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultTimeout bounds every operation whose timeouts block does not set
+// its own limit, so a rate limited API cannot hang a run forever.
+const defaultTimeout = 20 * time.Minute
+
+// timeoutsBlock is the standard timeouts block of the resources.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// nullTimeouts is the value of an unset timeouts block.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// withTimeout limits ctx to the timeout returned by timeout, one of the
+// Create, Read, Update or Delete methods of the configured timeouts. The
+// deadline propagates into every API request and wait made with the
+// returned context.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	limit, d := timeout(ctx, defaultTimeout)
+	diags.Append(d...)
+	return context.WithTimeout(ctx, limit)
+}